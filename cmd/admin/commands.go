@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/service"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/teamfile"
+)
+
+const (
+	defaultFairnessDays = 30
+	hoursPerDay         = 24
+)
+
+type command func(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error
+
+var commands = map[string]command{
+	"import-teams": importTeams,
+	"export-teams": exportTeams,
+	"set-active":   setActive,
+	"reassign":     reassign,
+	"add-reviewer": addReviewer,
+	"overloaded":   overloaded,
+}
+
+// parseFlags parses args and reports a usage error for flags or stray
+// arguments the command does not know.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(os.Stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+
+	return nil
+}
+
+func required(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("%w: -%s is required", errUsage, name)
+		}
+	}
+
+	return nil
+}
+
+func printJSON(out io.Writer, v any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func importTeams(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("import-teams", flag.ContinueOnError)
+	path := fs.String("file", "", "roster file (.json, .yaml, .yml or .csv)")
+	formatName := fs.String("format", "", "file format; defaults to the file extension")
+	dryRun := fs.Bool("dry-run", false, "only print the changes the import would make")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := required(fs, "file"); err != nil {
+		return err
+	}
+
+	format, err := teamfile.FormatFromPath(*path)
+	if *formatName != "" {
+		format, err = teamfile.ParseFormat(*formatName)
+	}
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	teams, err := teamfile.Parse(file, format)
+	if err != nil {
+		return err
+	}
+
+	report, err := s.ImportService.Import(ctx, teams, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(out, report)
+}
+
+func exportTeams(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export-teams", flag.ContinueOnError)
+	formatName := fs.String("format", string(teamfile.FormatJSON), "json, yaml or csv")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format, err := teamfile.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	teams, err := s.ImportService.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	return teamfile.Write(out, format, teams)
+}
+
+func setActive(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("set-active", flag.ContinueOnError)
+	userID := fs.String("user", "", "user id")
+	active := fs.Bool("active", true, "new status; deactivating reassigns open reviews")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := required(fs, "user"); err != nil {
+		return err
+	}
+
+	user, report, err := s.UserService.ChangeStatus(ctx, *userID, *active)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(out, map[string]any{
+		"user":         user,
+		"reassignment": report,
+	})
+}
+
+func reassign(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("reassign", flag.ContinueOnError)
+	prID := fs.String("pr", "", "pull request id")
+	userID := fs.String("user", "", "reviewer to replace")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := required(fs, "pr", "user"); err != nil {
+		return err
+	}
+
+	pr, replacedBy, err := s.PRService.ReassignReviewer(ctx, *prID, *userID)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(out, map[string]any{
+		"pr":          pr,
+		"replaced_by": replacedBy,
+	})
+}
+
+func addReviewer(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("add-reviewer", flag.ContinueOnError)
+	prID := fs.String("pr", "", "pull request id")
+	userID := fs.String("user", "", "reviewer to add; empty picks one automatically")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := required(fs, "pr"); err != nil {
+		return err
+	}
+
+	pr, added, err := s.PRService.AddReviewer(ctx, *prID, *userID)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(out, map[string]any{
+		"pr":    pr,
+		"added": added,
+	})
+}
+
+func overloaded(ctx context.Context, s *handlers.Services, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("overloaded", flag.ContinueOnError)
+	team := fs.String("team", "", "team name")
+	days := fs.Int("days", defaultFairnessDays, "length of the period ending now, in days")
+	threshold := fs.Float64("threshold", service.DefaultFairnessThreshold,
+		"relative deviation above the expected share that counts as overloaded")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := required(fs, "team"); err != nil {
+		return err
+	}
+
+	if *days <= 0 || *threshold <= 0 {
+		return fmt.Errorf("%w: -days and -threshold must be positive", errUsage)
+	}
+
+	to := time.Now()
+	from := to.Add(-time.Duration(*days) * hoursPerDay * time.Hour)
+
+	report, err := s.StatsService.GetFairness(ctx, *team, from, to, *threshold)
+	if err != nil {
+		return err
+	}
+
+	members := make([]*entity.FairnessMember, 0, len(report.Overloaded))
+	for _, member := range report.Members {
+		for _, id := range report.Overloaded {
+			if member.UserID == id {
+				members = append(members, member)
+			}
+		}
+	}
+
+	return printJSON(out, map[string]any{
+		"team_name":  report.TeamName,
+		"from":       report.From,
+		"to":         report.To,
+		"threshold":  report.Threshold,
+		"overloaded": members,
+	})
+}
@@ -0,0 +1,92 @@
+// Command admin runs operational tasks against the service's database using
+// the same service layer as the HTTP API:
+//
+//	admin import-teams -file teams.yaml [-dry-run]
+//	admin export-teams [-format yaml] > teams.yaml
+//	admin set-active -user u1 -active=false
+//	admin reassign -pr pr-1 -user u1
+//	admin add-reviewer -pr pr-1 [-user u2]
+//	admin overloaded -team backend [-days 30] [-threshold 0.5]
+//	admin migrate up | down [steps] | version
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/config"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/app"
+	logger "Service-for-assigning-reviewers-for-Pull-Requests/pkg/logger"
+)
+
+const (
+	exitCodeError = 1
+	exitCodeUsage = 2
+)
+
+const usage = `usage: admin <command> [flags]
+
+commands:
+  import-teams  create or update teams and members from a JSON, YAML or CSV file
+  export-teams  print all teams in the import format
+  set-active    activate or deactivate a user, reassigning their reviews
+  reassign      replace a reviewer on a pull request
+  add-reviewer  add a given or automatically chosen reviewer to a pull request
+  overloaded    list reviewers above their fair share of reviews in a team
+  migrate       apply or revert schema migrations (up | down [steps] | version)
+`
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitCodeUsage)
+	}
+
+	log := logger.SetupLogger()
+	if err := config.SystemVarsInit(); err != nil {
+		log.Error(fmt.Sprintf("failed to load .env file by error %v", err))
+		os.Exit(exitCodeError)
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to configuration by error %v", err))
+		os.Exit(exitCodeError)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	if command == "migrate" {
+		err = app.Migrate(cfg, log, args)
+	} else {
+		err = runCommand(context.Background(), cfg, command, args)
+	}
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitCodeUsage)
+	}
+
+	if err != nil {
+		log.Error(fmt.Sprintf("%s failed: %v", command, err))
+		os.Exit(exitCodeError)
+	}
+}
+
+func runCommand(ctx context.Context, cfg *config.Config, command string, args []string) error {
+	cmd, ok := commands[command]
+	if !ok {
+		return errUsage
+	}
+
+	s, closeDB, err := app.OpenServices(cfg, logger.SetupLogger())
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	return cmd(ctx, s, args, os.Stdout)
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	// embedded zone database for users' working hours in minimal images
+	_ "time/tzdata"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/config"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/app"
@@ -24,6 +26,25 @@ func main() {
 		os.Exit(exitCodeError)
 	}
 
+	log, err = logger.New(os.Stdout, logger.Options{
+		Level:            cfg.Log.Level,
+		Format:           cfg.Log.Format,
+		DebugSampleEvery: cfg.Log.DebugSampleEvery,
+	})
+	if err != nil {
+		logger.SetupLogger().Error(fmt.Sprintf("failed to configure logger by error %v", err))
+		os.Exit(exitCodeError)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = app.Migrate(cfg, log, os.Args[2:]); err != nil {
+			log.Error(fmt.Sprintf("error running migrations: %v", err))
+			os.Exit(exitCodeError)
+		}
+
+		return
+	}
+
 	if err = app.Run(cfg, log); err != nil {
 		log.Error(fmt.Sprintf("error running app: %v", err))
 		os.Exit(exitCodeError)
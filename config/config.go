@@ -8,9 +8,61 @@ import (
 )
 
 type Config struct {
-	Server   HTTPServer `mapstructure:"server"`
-	App      App        `mapstructure:"app"`
-	Database DB         `mapstructure:"database"`
+	Server     HTTPServer `mapstructure:"server"`
+	App        App        `mapstructure:"app"`
+	Database   DB         `mapstructure:"database"`
+	Jobs       Jobs       `mapstructure:"jobs"`
+	Assignment Assignment `mapstructure:"assignment"`
+	Tracing    Tracing    `mapstructure:"tracing"`
+	Log        Log        `mapstructure:"log"`
+}
+
+type Log struct {
+	// Level is debug, info, warn or error.
+	Level string `mapstructure:"level"`
+	// Format is json or text.
+	Format string `mapstructure:"format"`
+	// DebugSampleEvery keeps one in every N debug records; 0 keeps all.
+	DebugSampleEvery uint64 `mapstructure:"debug_sample_every"`
+}
+
+// Tracing configures OpenTelemetry span export; spans are no-ops when
+// disabled.
+type Tracing struct {
+	// Exporter is stdout or otlp.
+	Exporter string `mapstructure:"exporter"`
+	// Endpoint is the OTLP/HTTP collector host:port.
+	Endpoint    string  `mapstructure:"endpoint"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	Enabled     bool    `mapstructure:"enabled"`
+	Insecure    bool    `mapstructure:"insecure"`
+}
+
+// Assignment tunes how reviewers are picked for pull requests.
+type Assignment struct {
+	WorkingHoursSLA time.Duration `mapstructure:"working_hours_sla"`
+	// PairRotationWindow is how far back author/reviewer pairs are counted
+	// when RotatePairs is on.
+	PairRotationWindow time.Duration `mapstructure:"pair_rotation_window"`
+	// OverdueAction is applied to reviews exceeding the team SLA:
+	// flag, reassign or escalate.
+	OverdueAction string `mapstructure:"overdue_action"`
+	// Seed fixes the source the seed of every pick is drawn from, so a run
+	// can be replayed as a whole; 0 uses a random source.
+	Seed               int64 `mapstructure:"seed"`
+	PreferWorkingHours bool  `mapstructure:"prefer_working_hours"`
+	RotatePairs        bool  `mapstructure:"rotate_pairs"`
+}
+
+// Jobs holds background job intervals; a zero interval disables the job.
+type Jobs struct {
+	AvailabilityInterval time.Duration `mapstructure:"availability_interval"`
+	SLAInterval          time.Duration `mapstructure:"sla_interval"`
+	// SnapshotInterval refreshes today's stats snapshot; the last run of a
+	// day becomes that day's value.
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
+	// MetricsInterval refreshes the aggregated gauges served by /metrics.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
 }
 
 type App struct {
@@ -27,6 +79,9 @@ type DB struct {
 	Schema            string         `mapstructure:"schema"`
 	Port              int            `mapstructure:"port"`
 	MaxPoolSize       int            `mapstructure:"maxpoolsize"`
+	// MigrateOnStart applies pending migrations before serving; otherwise
+	// run `app migrate up`.
+	MigrateOnStart bool `mapstructure:"migrate_on_start"`
 }
 
 type HTTPServer struct {
@@ -34,6 +89,9 @@ type HTTPServer struct {
 	WriteTimeout    *time.Duration `mapstructure:"write_timeout"`
 	Addr            string         `mapstructure:"addr"`
 	ShutdownTimeout time.Duration  `mapstructure:"shutdown_timeout"`
+	// DrainDelay is how long /health/ready reports not-ready before the
+	// server stops accepting connections.
+	DrainDelay time.Duration `mapstructure:"drain_delay"`
 }
 
 type Mode string
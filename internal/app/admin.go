@@ -0,0 +1,27 @@
+package app
+
+import (
+	"log/slog"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/config"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+// OpenServices connects to the database and builds the service layer the
+// HTTP handlers use, for tools running outside the server. close releases
+// the pool.
+func OpenServices(cfg *config.Config, logger *slog.Logger) (*handlers.Services, func(), error) {
+	db, err := initPostgres(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts, err := serviceOptions(cfg)
+	if err != nil {
+		db.Close()
+
+		return nil, nil, err
+	}
+
+	return handlers.CreateNewService(initDBRepository(db), logger, opts), db.Close, nil
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/config"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/health"
+)
+
+// runPeriodic calls fn right away and then every interval until ctx is
+// cancelled, reporting each run to jobs. A non-positive interval disables the
+// job.
+func runPeriodic(
+	ctx context.Context,
+	logger *slog.Logger,
+	jobs *health.Jobs,
+	name string,
+	interval time.Duration,
+	fn func(ctx context.Context) error,
+) {
+	if interval <= zero {
+		logger.Info("background job disabled", "job", name)
+		return
+	}
+
+	jobs.Register(name, interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			err := fn(ctx)
+			if err != nil {
+				logger.Error("background job failed", "job", name, "error", err)
+			}
+
+			jobs.Report(name, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func startJobs(
+	ctx context.Context,
+	cfg *config.Config,
+	s *handlers.Services,
+	logger *slog.Logger,
+	jobs *health.Jobs,
+) {
+	runPeriodic(ctx, logger, jobs, "availability_handover", cfg.Jobs.AvailabilityInterval,
+		func(ctx context.Context) error {
+			handled, err := s.AvailabilityService.ProcessStartedWindows(ctx)
+			if handled > zero {
+				logger.Info("handed over reviews for started unavailability windows",
+					"windows", handled)
+			}
+
+			return err
+		})
+
+	runPeriodic(ctx, logger, jobs, "sla_check", cfg.Jobs.SLAInterval,
+		func(ctx context.Context) error {
+			handled, err := s.SLAService.CheckOverdue(ctx)
+			if handled > zero {
+				logger.Info("handled overdue reviews", "reviews", handled)
+			}
+
+			return err
+		})
+
+	runPeriodic(ctx, logger, jobs, "stats_snapshot", cfg.Jobs.SnapshotInterval,
+		s.HistoryService.TakeSnapshot)
+
+	runPeriodic(ctx, logger, jobs, "metrics_collector", cfg.Jobs.MetricsInterval,
+		s.UpdateMetrics)
+}
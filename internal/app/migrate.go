@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/config"
+	"Service-for-assigning-reviewers-for-Pull-Requests/migrations"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/migrate"
+)
+
+// initialVersion is the migration matching the schema the old init script
+// created; databases set up by it are adopted rather than re-created.
+const initialVersion = 1
+
+var errMigrateUsage = errors.New("usage: migrate up | down [steps] | version")
+
+func newMigrator(db *database.DatabaseSource) (*migrate.Migrator, error) {
+	m, err := migrate.New(db.Pool, migrations.FS, migrate.WithBaseline(initialVersion, "teams"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return m, nil
+}
+
+// Migrate runs the migrate subcommand: up, down [steps] (default 1) or
+// version.
+func Migrate(cfg *config.Config, logger *slog.Logger, args []string) error {
+	if len(args) == zero {
+		return errMigrateUsage
+	}
+
+	db, err := initPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("migrations applied", "applied", applied, "version", m.Latest())
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= zero {
+				return errMigrateUsage
+			}
+		}
+
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("migrations reverted", "reverted", reverted)
+	case "version":
+		version, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("schema version", "version", version, "latest", m.Latest())
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand"
 	"time"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/config"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
 
 	"github.com/go-chi/chi/v5"
 
 	//nolint:revive // dependency
 	postgres "Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/service"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/health"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
 	server "Service-for-assigning-reviewers-for-Pull-Requests/pkg/server"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+
+	"github.com/jackc/pgx/v5/multitracer"
 )
 
 const (
@@ -24,6 +32,7 @@ const (
 func initPostgres(cfg *config.Config) (*database.DatabaseSource, error) {
 	opts := []database.Option{
 		database.SetMaxPoolSize(cfg.Database.MaxPoolSize),
+		database.SetTracer(multitracer.New(metrics.QueryTracer{}, tracing.QueryTracer{})),
 	}
 
 	if cfg.Database.MaxConnLifetime != nil {
@@ -71,7 +80,63 @@ func initDBRepository(db *database.DatabaseSource) *postgres.Repository {
 	return postgres.CreateNewDBRepository(db)
 }
 
+func serviceOptions(cfg *config.Config) (handlers.ServiceOptions, error) {
+	var opts handlers.ServiceOptions
+
+	if cfg.Assignment.PreferWorkingHours {
+		opts.PR = append(opts.PR, service.WithWorkingHours(cfg.Assignment.WorkingHoursSLA))
+	}
+
+	if cfg.Assignment.RotatePairs {
+		opts.PR = append(opts.PR, service.WithPairRotation(cfg.Assignment.PairRotationWindow))
+	}
+
+	if cfg.Assignment.Seed != zero {
+		//nolint:gosec // a fixed seed is the point, not cryptographic randomness
+		opts.PR = append(opts.PR, service.WithRandSource(rand.NewSource(cfg.Assignment.Seed)))
+	}
+
+	action, err := entity.ParseSLAAction(cfg.Assignment.OverdueAction)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.SLAAction = action
+
+	return opts, nil
+}
+
+func initTracing(cfg *config.Config) (func(context.Context) error, error) {
+	if !cfg.Tracing.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: cfg.App.Name,
+		Version:     cfg.App.Version,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		Insecure:    cfg.Tracing.Insecure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	return shutdown, nil
+}
+
 func Run(cfg *config.Config, logger *slog.Logger) error {
+	shutdownTracing, err := initTracing(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	db, err := initPostgres(cfg)
 	if err != nil {
 		return err
@@ -80,11 +145,42 @@ func Run(cfg *config.Config, logger *slog.Logger) error {
 		db.Close()
 	}(db)
 
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Database.MigrateOnStart {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+
+		logger.Info("schema migrated", "applied", applied, "version", migrator.Latest())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	pgRepository := initDBRepository(db)
-	s := handlers.CreateNewService(pgRepository, logger)
+	opts, err := serviceOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	s := handlers.CreateNewService(pgRepository, logger, opts)
+	jobs := health.NewJobs()
+	startJobs(ctx, cfg, s, logger, jobs)
+
+	checker := health.NewChecker()
+	checker.Add("database", db.HealthCheck)
+	checker.Add("schema", health.SchemaCheck(migrator.Version, migrator.Latest()))
+	checker.Add("jobs", jobs.Check)
+
 	r := chi.NewMux()
 	server.RegisterRoutes(s, r)
-	srv := server.StartServer(cfg, r, logger)
+	server.RegisterHealthRoutes(checker, r)
+	srv := server.StartServer(cfg, r, logger, server.OnShutdown(checker.SetShuttingDown))
 
 	go func() {
 		<-time.After(cfg.Server.ShutdownTimeout)
@@ -0,0 +1,31 @@
+package entity
+
+type BulkStatus string
+
+const (
+	BulkStatusUpdated   BulkStatus = "updated"
+	BulkStatusUnchanged BulkStatus = "unchanged"
+	BulkStatusNotFound  BulkStatus = "not_found"
+	BulkStatusFailed    BulkStatus = "failed"
+)
+
+type StatusChange struct {
+	UserID   string `json:"user_id"`
+	IsActive bool   `json:"is_active"`
+}
+
+// BulkStatusResult is the outcome for one user of a bulk status update.
+type BulkStatusResult struct {
+	UserID   string     `json:"user_id"`
+	TeamName string     `json:"team_name,omitempty"`
+	Status   BulkStatus `json:"status"`
+	Error    string     `json:"error,omitempty"`
+	IsActive bool       `json:"is_active"`
+}
+
+type BulkStatusReport struct {
+	Results   []BulkStatusResult `json:"results"`
+	Updated   int                `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+	Failed    int                `json:"failed"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// ReviewerConflict is a pair of users who must never review each other's
+// PRs. The pair is symmetric: listing it for either user puts that user in
+// UserID.
+type ReviewerConflict struct {
+	CreatedAt   time.Time `json:"created_at"`
+	UserID      string    `json:"user_id"`
+	OtherUserID string    `json:"other_user_id"`
+	Reason      string    `json:"reason,omitempty"`
+}
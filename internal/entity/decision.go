@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// AssignmentDecision records one automatic reviewer pick. Shuffling
+// Candidates with a rand.Source seeded by Seed gives the random order; the
+// ranking options (pair rotation from Pairings, working hours at DecidedAt)
+// turn it into Ranking, whose first entries were Picked.
+type AssignmentDecision struct {
+	DecidedAt     time.Time      `json:"decided_at"`
+	Pairings      map[string]int `json:"pairings,omitempty"`
+	PullRequestID string         `json:"pull_request_id"`
+	Operation     string         `json:"operation"`
+	Candidates    []string       `json:"candidates"`
+	// Shuffled is the random order replayed from Seed when explained; it is
+	// not stored.
+	Shuffled []string `json:"shuffled,omitempty"`
+	Ranking  []string `json:"ranking"`
+	Picked   []string `json:"picked"`
+	ID       int64    `json:"id"`
+	Seed     int64    `json:"seed"`
+}
+
+// AssignmentExplanation lists the recorded picks that led to a PR's
+// reviewers, oldest first. Reviewers set explicitly are not picks.
+type AssignmentExplanation struct {
+	PullRequestID     string                `json:"pull_request_id"`
+	AssignedReviewers []string              `json:"assigned_reviewers"`
+	Decisions         []*AssignmentDecision `json:"decisions"`
+}
@@ -3,15 +3,21 @@ package entity
 import "errors"
 
 var (
-	ErrPRExists                = errors.New("PR_EXISTS")
-	ErrNotFound                = errors.New("NOT_FOUND")
-	ErrPRMerged                = errors.New("PR_MERGED")
-	ErrTeamExists              = errors.New("TEAM_EXISTS")
-	ErrNotAssigned             = errors.New("NOT_ASSIGNED")
-	ErrNoCandidate             = errors.New("NO_CANDIDATE")
-	ErrEmptyRequest            = errors.New("EMPTY_REQUEST")
-	ErrUsersFromDifferentTeams = errors.New("USERS_FROM_DIFFERENT_TEAMS")
-	ErrOnlyDeactivate          = errors.New("ONLY_DEACTIVATE")
+	ErrPRExists            = errors.New("PR_EXISTS")
+	ErrNotFound            = errors.New("NOT_FOUND")
+	ErrPRMerged            = errors.New("PR_MERGED")
+	ErrTeamExists          = errors.New("TEAM_EXISTS")
+	ErrNotAssigned         = errors.New("NOT_ASSIGNED")
+	ErrNoCandidate         = errors.New("NO_CANDIDATE")
+	ErrEmptyRequest        = errors.New("EMPTY_REQUEST")
+	ErrTeamMismatch        = errors.New("TEAM_MISMATCH")
+	ErrOnlyDeactivate      = errors.New("ONLY_DEACTIVATE")
+	ErrRepositoryExists    = errors.New("REPOSITORY_EXISTS")
+	ErrLeadNotMember       = errors.New("LEAD_NOT_MEMBER")
+	ErrInvalidDelegate     = errors.New("INVALID_DELEGATE")
+	ErrInvalidReviewer     = errors.New("INVALID_REVIEWER")
+	ErrReviewerLimit       = errors.New("REVIEWER_LIMIT")
+	ErrReviewerUnavailable = errors.New("REVIEWER_UNAVAILABLE")
 )
 
 type ErrorResponse struct {
@@ -26,15 +32,21 @@ type ErrorDetail struct {
 type ErrorCode string
 
 const (
-	CodeOnlyDeactivate          ErrorCode = "ONLY_DEACTIVATE"
-	CodeEmptyRequest            ErrorCode = "EMPTY_REQUEST"
-	CodeUsersFromDifferentTeams ErrorCode = "USERS_FROM_DIFFERENT_TEAMS"
-	CodeTeamExists              ErrorCode = "TEAM_EXISTS"
-	CodePRExists                ErrorCode = "PR_EXISTS"
-	CodePRMerged                ErrorCode = "PR_MERGED"
-	CodeNotAssigned             ErrorCode = "NOT_ASSIGNED"
-	CodeNoCandidate             ErrorCode = "NO_CANDIDATE"
-	CodeNotFound                ErrorCode = "NOT_FOUND"
-	CodeBadRequest              ErrorCode = "BAD_REQUEST"
-	CodeInternalError           ErrorCode = "INTERNAL_ERROR"
+	CodeOnlyDeactivate      ErrorCode = "ONLY_DEACTIVATE"
+	CodeEmptyRequest        ErrorCode = "EMPTY_REQUEST"
+	CodeTeamMismatch        ErrorCode = "TEAM_MISMATCH"
+	CodeTeamExists          ErrorCode = "TEAM_EXISTS"
+	CodeRepositoryExists    ErrorCode = "REPOSITORY_EXISTS"
+	CodeLeadNotMember       ErrorCode = "LEAD_NOT_MEMBER"
+	CodeInvalidDelegate     ErrorCode = "INVALID_DELEGATE"
+	CodeInvalidReviewer     ErrorCode = "INVALID_REVIEWER"
+	CodeReviewerLimit       ErrorCode = "REVIEWER_LIMIT"
+	CodeReviewerUnavailable ErrorCode = "REVIEWER_UNAVAILABLE"
+	CodePRExists            ErrorCode = "PR_EXISTS"
+	CodePRMerged            ErrorCode = "PR_MERGED"
+	CodeNotAssigned         ErrorCode = "NOT_ASSIGNED"
+	CodeNoCandidate         ErrorCode = "NO_CANDIDATE"
+	CodeNotFound            ErrorCode = "NOT_FOUND"
+	CodeBadRequest          ErrorCode = "BAD_REQUEST"
+	CodeInternalError       ErrorCode = "INTERNAL_ERROR"
 )
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// FairnessMember describes how many reviews a team member received compared
+// with the share expected from the days they were available.
+type FairnessMember struct {
+	UserID      string  `json:"user_id"`
+	Assignments int     `json:"assignments"`
+	ActiveDays  float64 `json:"active_days"`
+	// Expected is the number of assignments the member would get if the
+	// team total were split proportionally to active days.
+	Expected float64 `json:"expected"`
+	// Deviation is (assignments - expected) / expected; zero when nothing
+	// was expected.
+	Deviation float64 `json:"deviation"`
+	IsActive  bool    `json:"is_active"`
+}
+
+type FairnessReport struct {
+	From             time.Time         `json:"from"`
+	To               time.Time         `json:"to"`
+	TeamName         string            `json:"team_name"`
+	Members          []*FairnessMember `json:"members"`
+	Overloaded       []string          `json:"overloaded"`
+	Underloaded      []string          `json:"underloaded"`
+	Gini             float64           `json:"gini"`
+	Threshold        float64           `json:"threshold"`
+	TotalAssignments int               `json:"total_assignments"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+type ImportChangeKind string
+
+const (
+	ImportTeam ImportChangeKind = "team"
+	ImportUser ImportChangeKind = "user"
+)
+
+// ImportChange is one entry of an import diff. Fields lists what an update
+// changes; FromTeam is set for users moving between teams.
+type ImportChange struct {
+	Kind     ImportChangeKind `json:"kind"`
+	TeamName string           `json:"team_name"`
+	UserID   string           `json:"user_id,omitempty"`
+	FromTeam string           `json:"from_team,omitempty"`
+	Fields   []string         `json:"fields,omitempty"`
+}
+
+type ImportReport struct {
+	Creates   []ImportChange `json:"creates"`
+	Updates   []ImportChange `json:"updates"`
+	Moves     []ImportChange `json:"moves"`
+	Unchanged int            `json:"unchanged"`
+	DryRun    bool           `json:"dry_run"`
+	Applied   bool           `json:"applied"`
+}
@@ -16,7 +16,9 @@ type PullRequest struct {
 	PullRequestName   string     `json:"pull_request_name"`
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
+	RepositoryName    string     `json:"repository_name,omitempty"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	Number            int        `json:"number,omitempty"`
 }
 
 type PullRequestShort struct {
@@ -24,4 +26,19 @@ type PullRequestShort struct {
 	PullRequestName string   `json:"pull_request_name"`
 	AuthorID        string   `json:"author_id"`
 	Status          PRStatus `json:"status"`
+	RepositoryName  string   `json:"repository_name,omitempty"`
+	Number          int      `json:"number,omitempty"`
+}
+
+// PRCreateParams describes a new PR. Either PullRequestID or the
+// RepositoryName/Number pair identifies it. RequiredReviewers are always
+// assigned and ExcludedReviewers never are; selection fills the rest.
+type PRCreateParams struct {
+	PullRequestID     string
+	PullRequestName   string
+	AuthorID          string
+	RepositoryName    string
+	RequiredReviewers []string
+	ExcludedReviewers []string
+	Number            int
 }
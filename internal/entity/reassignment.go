@@ -0,0 +1,73 @@
+package entity
+
+import "slices"
+
+// PRReassignment describes how the reviewers of one open PR changed when some
+// of them were taken off it.
+type PRReassignment struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Removed       []string `json:"removed_reviewers"`
+	Added         []string `json:"added_reviewers"`
+	Reviewers     []string `json:"reviewers"`
+	Understaffed  bool     `json:"understaffed"`
+}
+
+// ReassignmentReport lists the PRs touched by a deactivation. Understaffed
+// PRs ended up with fewer reviewers than before; WithoutReviewers is the
+// subset left with none. A dry run report describes changes that were
+// planned and rolled back.
+type ReassignmentReport struct {
+	DeactivatedUserIDs []string          `json:"deactivated_user_ids"`
+	PullRequests       []*PRReassignment `json:"pull_requests"`
+	Understaffed       []string          `json:"understaffed_pull_requests"`
+	WithoutReviewers   []string          `json:"without_reviewers"`
+	DryRun             bool              `json:"dry_run"`
+}
+
+func NewReassignmentReport() *ReassignmentReport {
+	return &ReassignmentReport{
+		DeactivatedUserIDs: []string{},
+		PullRequests:       []*PRReassignment{},
+		Understaffed:       []string{},
+		WithoutReviewers:   []string{},
+	}
+}
+
+// Add records change and files it under the understaffed lists.
+func (r *ReassignmentReport) Add(change *PRReassignment) {
+	r.PullRequests = append(r.PullRequests, change)
+
+	if change.Understaffed {
+		r.Understaffed = append(r.Understaffed, change.PullRequestID)
+	}
+
+	if len(change.Reviewers) == 0 {
+		r.WithoutReviewers = append(r.WithoutReviewers, change.PullRequestID)
+	}
+}
+
+// DiffReviewers describes the change of a PR's reviewers from before to
+// after.
+func DiffReviewers(prID string, before, after []string) *PRReassignment {
+	change := &PRReassignment{
+		PullRequestID: prID,
+		Removed:       []string{},
+		Added:         []string{},
+		Reviewers:     append([]string{}, after...),
+		Understaffed:  len(after) < len(before),
+	}
+
+	for _, id := range before {
+		if !slices.Contains(after, id) {
+			change.Removed = append(change.Removed, id)
+		}
+	}
+
+	for _, id := range after {
+		if !slices.Contains(before, id) {
+			change.Added = append(change.Added, id)
+		}
+	}
+
+	return change
+}
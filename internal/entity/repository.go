@@ -0,0 +1,17 @@
+package entity
+
+import "fmt"
+
+const DefaultVCS = "git"
+
+type Repository struct {
+	RepositoryName string `json:"repository_name"`
+	VCS            string `json:"vcs"`
+	DefaultTeam    string `json:"default_team,omitempty"`
+}
+
+// PRKey builds the pull_request_id used for PRs created inside a repository,
+// so numbers only have to be unique per repository.
+func PRKey(repositoryName string, number int) string {
+	return fmt.Sprintf("%s#%d", repositoryName, number)
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SLAAction is what the overdue checker does with a review exceeding its
+// team's SLA.
+type SLAAction string
+
+const (
+	SLAActionFlag     SLAAction = "flag"
+	SLAActionReassign SLAAction = "reassign"
+	SLAActionEscalate SLAAction = "escalate"
+)
+
+// ParseSLAAction validates a configured action; empty means flag only.
+func ParseSLAAction(s string) (SLAAction, error) {
+	switch action := SLAAction(s); action {
+	case "":
+		return SLAActionFlag, nil
+	case SLAActionFlag, SLAActionReassign, SLAActionEscalate:
+		return action, nil
+	default:
+		return "", errors.New("unknown sla action " + s)
+	}
+}
+
+// OverdueReview is an open review assigned longer than the reviewer's team
+// SLA allows.
+type OverdueReview struct {
+	AssignedAt      time.Time  `json:"assigned_at"`
+	FlaggedAt       *time.Time `json:"flagged_at,omitempty"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	ReviewerID      string     `json:"reviewer_id"`
+	TeamName        string     `json:"team_name"`
+	LeadUserID      string     `json:"lead_user_id,omitempty"`
+	SLAMinutes      int        `json:"sla_minutes"`
+}
+
+type OverdueReport struct {
+	PerTeam map[string]int   `json:"per_team"`
+	Reviews []*OverdueReview `json:"reviews"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// StatsMetric names an aggregate stored in daily statistics snapshots.
+type StatsMetric string
+
+const (
+	MetricOpenPRs           StatsMetric = "open_prs"
+	MetricReviewersPerPR    StatsMetric = "reviewers_per_pr"
+	MetricOpenReviewsByUser StatsMetric = "open_reviews_per_user"
+	MetricMerges            StatsMetric = "merges"
+)
+
+// StatsMetrics lists every metric written by a snapshot.
+var StatsMetrics = []StatsMetric{
+	MetricOpenPRs,
+	MetricReviewersPerPR,
+	MetricOpenReviewsByUser,
+	MetricMerges,
+}
+
+type StatsPoint struct {
+	Date    time.Time `json:"date"`
+	Subject string    `json:"-"`
+	Value   float64   `json:"value"`
+}
+
+// StatsSeries is the history of one metric; Subject is the user for
+// per-user metrics and empty otherwise.
+type StatsSeries struct {
+	Subject string        `json:"subject,omitempty"`
+	Points  []*StatsPoint `json:"points"`
+}
+
+type StatsHistory struct {
+	From   time.Time      `json:"from"`
+	To     time.Time      `json:"to"`
+	Metric StatsMetric    `json:"metric"`
+	Series []*StatsSeries `json:"series"`
+}
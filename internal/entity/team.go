@@ -1,14 +1,21 @@
 package entity
 
 type Team struct {
-	TeamName string       `db:"team_name" json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	DefaultMaxOpenReviews *int         `db:"default_max_open_reviews" json:"default_max_open_reviews,omitempty"`
+	ReviewSLAMinutes      *int         `db:"review_sla_minutes" json:"review_sla_minutes,omitempty"`
+	TeamName              string       `db:"team_name" json:"team_name"`
+	LeadUserID            string       `db:"lead_user_id" json:"lead_user_id,omitempty"`
+	Members               []TeamMember `json:"members"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TimeZone       string `json:"time_zone,omitempty"`
+	WorkStart      string `json:"work_start,omitempty"`
+	WorkEnd        string `json:"work_end,omitempty"`
+	IsActive       bool   `json:"is_active"`
 }
 
 type TeamNameQuery struct {
@@ -0,0 +1,12 @@
+package entity
+
+// TeamLoad aggregates the open review workload of a team for metrics.
+type TeamLoad struct {
+	TeamName string
+	// OpenPRs counts open PRs authored by team members.
+	OpenPRs int
+	// OpenReviews counts open reviews assigned to team members.
+	OpenReviews      int
+	MaxMemberReviews int
+	ActiveMembers    int
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// TurnaroundGroupBy selects whether turnaround is aggregated per team or
+// per user.
+type TurnaroundGroupBy string
+
+const (
+	GroupByTeam TurnaroundGroupBy = "team"
+	GroupByUser TurnaroundGroupBy = "user"
+)
+
+// DurationStats summarises a set of durations in seconds; percentiles are
+// nil when the set is empty.
+type DurationStats struct {
+	MedianSeconds *float64 `json:"median_seconds,omitempty"`
+	P90Seconds    *float64 `json:"p90_seconds,omitempty"`
+	Count         int      `json:"count"`
+}
+
+// TurnaroundGroup holds timings of one team or user. PR timings are grouped
+// by the author, review durations by the reviewer.
+type TurnaroundGroup struct {
+	Key                   string        `json:"key"`
+	TimeToFirstAssignment DurationStats `json:"time_to_first_assignment"`
+	TimeToMerge           DurationStats `json:"time_to_merge"`
+	ReviewOpenDuration    DurationStats `json:"review_open_duration"`
+}
+
+type TurnaroundReport struct {
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	GroupBy TurnaroundGroupBy  `json:"group_by"`
+	Groups  []*TurnaroundGroup `json:"groups"`
+}
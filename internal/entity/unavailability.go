@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Unavailability is a scheduled window (vacation, out-of-office) during which
+// the user is not picked as a reviewer.
+type Unavailability struct {
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	UserID     string    `json:"user_id"`
+	Reason     string    `json:"reason,omitempty"`
+	ID         int64     `json:"id"`
+	Handover   bool      `json:"handover"`
+	HandedOver bool      `json:"handed_over"`
+}
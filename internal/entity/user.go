@@ -1,10 +1,14 @@
 package entity
 
 type User struct {
-	UserID   string `db:"user_id"`
-	Username string `db:"username"`
-	TeamName string `db:"team_name"`
-	IsActive bool   `db:"is_active"`
+	MaxOpenReviews *int   `db:"max_open_reviews" json:"max_open_reviews,omitempty"`
+	UserID         string `db:"user_id"`
+	Username       string `db:"username"`
+	TeamName       string `db:"team_name"`
+	TimeZone       string `db:"time_zone" json:"time_zone,omitempty"`
+	WorkStart      string `db:"work_start" json:"work_start,omitempty"`
+	WorkEnd        string `db:"work_end" json:"work_end,omitempty"`
+	IsActive       bool   `db:"is_active"`
 }
 
 type UserItem struct {
@@ -13,3 +17,25 @@ type UserItem struct {
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
 }
+
+// ReviewCapacity describes how many more open reviews a user can take.
+// Nil limits mean the user is not capped.
+type ReviewCapacity struct {
+	MaxOpenReviews *int `json:"max_open_reviews"`
+	Remaining      *int `json:"remaining"`
+	OpenReviews    int  `json:"open_reviews"`
+}
+
+func NewReviewCapacity(maxOpenReviews *int, openReviews int) *ReviewCapacity {
+	capacity := &ReviewCapacity{
+		MaxOpenReviews: maxOpenReviews,
+		OpenReviews:    openReviews,
+	}
+
+	if maxOpenReviews != nil {
+		remaining := max(*maxOpenReviews-openReviews, 0)
+		capacity.Remaining = &remaining
+	}
+
+	return capacity
+}
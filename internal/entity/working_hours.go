@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+)
+
+const WorkingHoursLayout = "15:04"
+
+// HasWorkingHours reports whether the user configured a working window.
+func (u *User) HasWorkingHours() bool {
+	return u.WorkStart != "" && u.WorkEnd != ""
+}
+
+// UntilWorkingHours returns how long it takes for the user's working window
+// to open in their time zone. It is zero inside the window and for users
+// without configured hours; windows crossing midnight are supported.
+func (u *User) UntilWorkingHours(now time.Time) time.Duration {
+	if !u.HasWorkingHours() {
+		return 0
+	}
+
+	loc, err := time.LoadLocation(u.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	start, err := time.Parse(WorkingHoursLayout, u.WorkStart)
+	if err != nil {
+		return 0
+	}
+
+	end, err := time.Parse(WorkingHoursLayout, u.WorkEnd)
+	if err != nil {
+		return 0
+	}
+
+	local := now.In(loc)
+	startToday := time.Date(local.Year(), local.Month(), local.Day(),
+		start.Hour(), start.Minute(), 0, 0, loc)
+	endToday := time.Date(local.Year(), local.Month(), local.Day(),
+		end.Hour(), end.Minute(), 0, 0, loc)
+
+	var inside bool
+	if !endToday.Before(startToday) {
+		inside = !local.Before(startToday) && local.Before(endToday)
+	} else {
+		inside = !local.Before(startToday) || local.Before(endToday)
+	}
+
+	if inside {
+		return 0
+	}
+
+	if !startToday.After(local) {
+		startToday = startToday.AddDate(0, 0, 1)
+	}
+
+	return startToday.Sub(local)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/teamfile"
+)
+
+const maxImportBodyBytes = 10 << 20
+
+// importFormat takes the format query parameter, falling back to the
+// request Content-Type and then JSON.
+func importFormat(r *http.Request) (teamfile.Format, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		return teamfile.ParseFormat(value)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(contentTypeHeader))
+	if err != nil {
+		return teamfile.FormatJSON, nil
+	}
+
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return teamfile.FormatYAML, nil
+	case "text/csv":
+		return teamfile.FormatCSV, nil
+	default:
+		return teamfile.FormatJSON, nil
+	}
+}
+
+// dryRunParam reads the dry_run query parameter, which defaults to fallback.
+func dryRunParam(r *http.Request, fallback bool) (bool, error) {
+	value := r.URL.Query().Get("dry_run")
+	if value == "" {
+		return fallback, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("dry_run must be a boolean")
+	}
+
+	return dryRun, nil
+}
+
+// AdminImportHandler creates or updates the teams and members in the body.
+// It only reports the diff unless dry_run=false.
+func (s *Services) AdminImportHandler(w http.ResponseWriter, r *http.Request) {
+	format, err := importFormat(r)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	dryRun, err := dryRunParam(r, true)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	teams, err := teamfile.Parse(http.MaxBytesReader(w, r.Body, maxImportBodyBytes), format)
+	if err != nil {
+		s.log(r).Warn("invalid import request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.ImportService.Import(r.Context(), teams, dryRun)
+	if err != nil {
+		s.log(r).Error("failed to import teams", errFieldName, err, "dry_run", dryRun)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	if report.Applied {
+		s.log(r).Info("teams imported", "creates", len(report.Creates),
+			"updates", len(report.Updates), "moves", len(report.Moves))
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode import response", ERROR, err)
+	}
+}
+
+// AdminExportHandler dumps all teams in the format /admin/import accepts.
+func (s *Services) AdminExportHandler(w http.ResponseWriter, r *http.Request) {
+	format := teamfile.FormatJSON
+	if value := r.URL.Query().Get("format"); value != "" {
+		var err error
+		if format, err = teamfile.ParseFormat(value); err != nil {
+			util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+			return
+		}
+	}
+
+	teams, err := s.ImportService.Export(r.Context())
+	if err != nil {
+		s.log(r).Error("failed to export teams", errFieldName, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="teams.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	if err := teamfile.Write(w, format, teams); err != nil {
+		s.log(r).Error("failed to write export", ERROR, err)
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+type UnavailabilityAddResponse struct {
+	Unavailability entity.Unavailability `json:"unavailability"`
+}
+
+type UnavailabilityListResponse struct {
+	UserID         string                  `json:"user_id"`
+	Unavailability []entity.Unavailability `json:"unavailability"`
+}
+
+type UnavailabilityDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+func validateUnavailabilityAddRequest(window *entity.Unavailability) error {
+	if strings.TrimSpace(window.UserID) == "" {
+		return errors.New("user_id is required")
+	}
+	if window.StartsAt.IsZero() || window.EndsAt.IsZero() {
+		return errors.New("starts_at and ends_at are required")
+	}
+	if !window.EndsAt.After(window.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	return nil
+}
+
+func (s *Services) UnavailabilityAddHandler(w http.ResponseWriter, r *http.Request) {
+	var req entity.Unavailability
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode unavailability add request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			invalidJSONMsg,
+		)
+
+		return
+	}
+
+	if err := validateUnavailabilityAddRequest(&req); err != nil {
+		s.log(r).Warn("invalid unavailability add request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error(),
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	window, err := s.AvailabilityService.AddUnavailability(ctx, &req)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for unavailability", userIDField, req.UserID)
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"user not found",
+			)
+
+			return
+		}
+
+		s.log(r).Error("failed to add unavailability",
+			errFieldName, err,
+			userIDField, req.UserID)
+
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error",
+		)
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(UnavailabilityAddResponse{Unavailability: *window}); err != nil {
+		s.log(r).Error("failed to encode unavailability add response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
+
+func (s *Services) UnavailabilityListHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get(userIDField)
+	if err := validateUserID(userID); err != nil {
+		s.log(r).Warn("invalid unavailability list request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error(),
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	windows, err := s.AvailabilityService.ListUnavailability(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for unavailability list", userIDField, userID)
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"user not found",
+			)
+
+			return
+		}
+
+		s.log(r).Error("failed to list unavailability",
+			errFieldName, err,
+			userIDField, userID)
+
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error",
+		)
+
+		return
+	}
+
+	resp := UnavailabilityListResponse{
+		UserID:         userID,
+		Unavailability: make([]entity.Unavailability, len(windows)),
+	}
+	for i, window := range windows {
+		resp.Unavailability[i] = *window
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log(r).Error("failed to encode unavailability list response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
+
+func (s *Services) UnavailabilityDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	var req UnavailabilityDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode unavailability delete request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			invalidJSONMsg,
+		)
+
+		return
+	}
+
+	if req.ID <= Zero {
+		s.log(r).Warn("invalid unavailability delete request")
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"id is required",
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := s.AvailabilityService.DeleteUnavailability(ctx, req.ID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("unavailability not found", "id", req.ID)
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"unavailability not found",
+			)
+
+			return
+		}
+
+		s.log(r).Error("failed to delete unavailability", errFieldName, err, "id", req.ID)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error",
+		)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
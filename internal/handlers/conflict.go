@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+type ConflictRequest struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type ConflictAddResponse struct {
+	Conflict entity.ReviewerConflict `json:"conflict"`
+}
+
+type ConflictListResponse struct {
+	UserID    string                    `json:"user_id,omitempty"`
+	Conflicts []entity.ReviewerConflict `json:"conflicts"`
+}
+
+func validateConflictRequest(req *ConflictRequest) error {
+	if strings.TrimSpace(req.UserID) == "" || strings.TrimSpace(req.OtherUserID) == "" {
+		return errors.New("user_id and other_user_id are required")
+	}
+	if req.UserID == req.OtherUserID {
+		return errors.New("user_id and other_user_id must differ")
+	}
+	return nil
+}
+
+// decodeConflictRequest reads and validates the body, answering 400 itself
+// when it is not usable.
+func (s *Services) decodeConflictRequest(w http.ResponseWriter, r *http.Request) (*ConflictRequest, bool) {
+	var req ConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode conflict request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, invalidJSONMsg)
+
+		return nil, false
+	}
+
+	if err := validateConflictRequest(&req); err != nil {
+		s.log(r).Warn("invalid conflict request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+
+		return nil, false
+	}
+
+	return &req, true
+}
+
+// ConflictAddHandler keeps the two users off each other's PRs from now on.
+func (s *Services) ConflictAddHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.decodeConflictRequest(w, r)
+	if !ok {
+		return
+	}
+
+	conflict, err := s.ConflictService.AddConflict(r.Context(), &entity.ReviewerConflict{
+		UserID:      req.UserID,
+		OtherUserID: req.OtherUserID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for conflict",
+				userIDField, req.UserID,
+				"other_user_id", req.OtherUserID)
+			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "user not found")
+
+			return
+		}
+
+		s.log(r).Error("failed to add conflict",
+			errFieldName, err,
+			userIDField, req.UserID,
+			"other_user_id", req.OtherUserID)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(ConflictAddResponse{Conflict: *conflict}); err != nil {
+		s.log(r).Error("failed to encode conflict add response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
+
+func (s *Services) ConflictRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.decodeConflictRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.ConflictService.DeleteConflict(r.Context(), req.UserID, req.OtherUserID); err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("conflict not found",
+				userIDField, req.UserID,
+				"other_user_id", req.OtherUserID)
+			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "conflict not found")
+
+			return
+		}
+
+		s.log(r).Error("failed to remove conflict",
+			errFieldName, err,
+			userIDField, req.UserID,
+			"other_user_id", req.OtherUserID)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ConflictListHandler lists the pairs of the user_id query parameter, or all
+// pairs without it.
+func (s *Services) ConflictListHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get(userIDField)
+
+	conflicts, err := s.ConflictService.ListConflicts(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for conflict list", userIDField, userID)
+			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "user not found")
+
+			return
+		}
+
+		s.log(r).Error("failed to list conflicts", errFieldName, err, userIDField, userID)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+
+		return
+	}
+
+	resp := ConflictListResponse{
+		UserID:    userID,
+		Conflicts: make([]entity.ReviewerConflict, len(conflicts)),
+	}
+	for i, conflict := range conflicts {
+		resp.Conflicts[i] = *conflict
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log(r).Error("failed to encode conflict list response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
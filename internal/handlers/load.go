@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"runtime/debug"
 	"strconv"
@@ -44,12 +43,11 @@ func validateLoadTestRequest(req *LoadTestRequest) error {
 func (s *Services) LoadTestHandler(w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		if rec := recover(); rec != nil {
-			fmt.Println("Panic in LoadTestHandler:", rec)
-			debug.PrintStack()
+			s.log(r).Error("panic in load test handler",
+				"panic", rec, "stack", string(debug.Stack()))
 		}
 	}()
 
-	fmt.Println(">>> LoadTestHandler start: r nil?", r == nil)
 	q := r.URL.Query()
 	req := LoadTestRequest{}
 	freqStr := q.Get("freq")
@@ -104,9 +102,11 @@ func (s *Services) LoadTestHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.log(r).Debug("starting load test", "freq", req.Freq, "duration", req.Duration)
+
 	rate := vegeta.Rate{Freq: req.Freq, Per: time.Second}
 	go s.LoadService.RunLoadTest(rate, req.Duration)
 	if _, err := w.Write([]byte("Load test started")); err != nil {
-		s.Log.Error("failed to write response", "error", err)
+		s.log(r).Error("failed to write response", "error", err)
 	}
 }
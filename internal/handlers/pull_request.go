@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
@@ -20,9 +21,13 @@ const (
 )
 
 type PRCreateRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	RepositoryName    string   `json:"repository_name,omitempty"`
+	RequiredReviewers []string `json:"required_reviewers,omitempty"`
+	ExcludedReviewers []string `json:"excluded_reviewers,omitempty"`
+	Number            int      `json:"number,omitempty"`
 }
 
 type PRCreateResponse struct {
@@ -30,26 +35,78 @@ type PRCreateResponse struct {
 }
 
 type PRReassignRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_user_id"`
+	PullRequestID  string `json:"pull_request_id"`
+	OldUserID      string `json:"old_user_id"`
+	RepositoryName string `json:"repository_name,omitempty"`
+	Number         int    `json:"number,omitempty"`
 }
 
 type PRReassignResponse struct {
-	ReplacedBy string             `json:"replaced_by"`
-	PR         entity.PullRequest `json:"pr"`
+	Reassignment *entity.ReassignmentReport `json:"reassignment,omitempty"`
+	ReplacedBy   string                     `json:"replaced_by"`
+	PR           entity.PullRequest         `json:"pr"`
+}
+
+// PRReviewerRequest names a reviewer to add to or remove from a PR. For
+// adding, an empty user_id picks one automatically.
+type PRReviewerRequest struct {
+	PullRequestID  string `json:"pull_request_id"`
+	UserID         string `json:"user_id"`
+	RepositoryName string `json:"repository_name,omitempty"`
+	Number         int    `json:"number,omitempty"`
+}
+
+type PRAddReviewerResponse struct {
+	Added string             `json:"added"`
+	PR    entity.PullRequest `json:"pr"`
+}
+
+type PRRemoveReviewerResponse struct {
+	PR entity.PullRequest `json:"pr"`
 }
 
 type PRMergeRequest struct {
-	PullRequestID string `json:"pull_request_id"`
+	PullRequestID  string `json:"pull_request_id"`
+	RepositoryName string `json:"repository_name,omitempty"`
+	Number         int    `json:"number,omitempty"`
 }
 
 type PRMergeResponse struct {
 	PR entity.PullRequest `json:"pr"`
 }
 
+// resolvePRID accepts both the legacy pull_request_id and the
+// repository_name/number pair, preferring the former when both are set.
+func resolvePRID(prID, repositoryName string, number int) string {
+	if strings.TrimSpace(prID) != "" {
+		return prID
+	}
+
+	if strings.TrimSpace(repositoryName) != "" && number > Zero {
+		return entity.PRKey(repositoryName, number)
+	}
+
+	return ""
+}
+
 func validatePRCreateRequest(req *PRCreateRequest) error {
-	if strings.TrimSpace(req.PullRequestID) == "" {
+	// '#' separates repository and number in PR keys, so a legacy id or a
+	// repository name containing it could collide with another PR's key
+	if strings.TrimSpace(req.RepositoryName) != "" {
+		if strings.Contains(req.RepositoryName, "#") {
+			return errors.New("repository_name must not contain '#'")
+		}
+		if req.Number <= Zero {
+			return errors.New("number must be positive")
+		}
+		if req.PullRequestID != "" &&
+			req.PullRequestID != entity.PRKey(req.RepositoryName, req.Number) {
+			return errors.New("pull_request_id does not match repository_name and number")
+		}
+	} else if strings.TrimSpace(req.PullRequestID) == "" {
 		return errors.New("pull_request_id is required")
+	} else if strings.Contains(req.PullRequestID, "#") {
+		return errors.New("pull_request_id must not contain '#' without repository_name")
 	}
 	if strings.TrimSpace(req.PullRequestName) == "" {
 		return errors.New("pull_request_name is required")
@@ -57,18 +114,37 @@ func validatePRCreateRequest(req *PRCreateRequest) error {
 	if strings.TrimSpace(req.AuthorID) == "" {
 		return errors.New("author_id is required")
 	}
+	return validateReviewerIDs(req.RequiredReviewers, req.ExcludedReviewers)
+}
+
+// validateReviewerIDs rejects empty and repeated ids across the given lists.
+func validateReviewerIDs(lists ...[]string) error {
+	seen := make(map[string]bool)
+	for _, ids := range lists {
+		for _, id := range ids {
+			if strings.TrimSpace(id) == "" {
+				return errors.New("reviewer user_id must not be empty")
+			}
+			if seen[id] {
+				return errors.New("reviewer " + id + " is listed more than once")
+			}
+			seen[id] = true
+		}
+	}
 	return nil
 }
 
 func validatePRMergeRequest(req *PRMergeRequest) error {
-	if strings.TrimSpace(req.PullRequestID) == "" {
+	req.PullRequestID = resolvePRID(req.PullRequestID, req.RepositoryName, req.Number)
+	if req.PullRequestID == "" {
 		return errors.New("pull_request_id is required")
 	}
 	return nil
 }
 
 func validatePRReassignRequest(req *PRReassignRequest) error {
-	if strings.TrimSpace(req.PullRequestID) == "" {
+	req.PullRequestID = resolvePRID(req.PullRequestID, req.RepositoryName, req.Number)
+	if req.PullRequestID == "" {
 		return errors.New("pull_request_id is required")
 	}
 	if strings.TrimSpace(req.OldUserID) == "" {
@@ -77,10 +153,21 @@ func validatePRReassignRequest(req *PRReassignRequest) error {
 	return nil
 }
 
+func validatePRReviewerRequest(req *PRReviewerRequest, userRequired bool) error {
+	req.PullRequestID = resolvePRID(req.PullRequestID, req.RepositoryName, req.Number)
+	if req.PullRequestID == "" {
+		return errors.New("pull_request_id is required")
+	}
+	if userRequired && strings.TrimSpace(req.UserID) == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
 func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 	var req PRCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode PR create request", "error", err)
+		s.log(r).Warn("failed to decode PR create request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -92,7 +179,7 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := validatePRCreateRequest(&req); err != nil {
-		s.Log.Warn("invalid PR create request", "error", err)
+		s.log(r).Warn("invalid PR create request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -105,14 +192,20 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	pr, _, err := s.PRService.CreatePR(ctx, req.PullRequestID,
-		req.PullRequestName,
-		req.AuthorID)
+	pr, _, err := s.PRService.CreatePR(ctx, entity.PRCreateParams{
+		PullRequestID:     req.PullRequestID,
+		PullRequestName:   req.PullRequestName,
+		AuthorID:          req.AuthorID,
+		RepositoryName:    req.RepositoryName,
+		RequiredReviewers: req.RequiredReviewers,
+		ExcludedReviewers: req.ExcludedReviewers,
+		Number:            req.Number,
+	})
 
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrPRExists):
-			s.Log.Info("attempt to create PR with existing ID", "pr_id",
+			s.log(r).Info("attempt to create PR with existing ID", "pr_id",
 				req.PullRequestID)
 
 			util.SendError(
@@ -123,8 +216,9 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 			)
 
 		case errors.Is(err, entity.ErrNotFound):
-			s.Log.Warn("author or team not found for PR creation",
-				"author_id", req.AuthorID)
+			s.log(r).Warn("author, team or repository not found for PR creation",
+				"author_id", req.AuthorID,
+				"repository_name", req.RepositoryName)
 
 			util.SendError(
 				w,
@@ -132,8 +226,16 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 				entity.CodeNotFound,
 				"author/team not found",
 			)
+		case errors.Is(err, entity.ErrInvalidReviewer):
+			s.log(r).Info("invalid pinned reviewers for PR creation", "error", err)
+			util.SendError(
+				w,
+				http.StatusBadRequest,
+				entity.CodeInvalidReviewer,
+				err.Error(),
+			)
 		default:
-			s.Log.Error("failed to create PR", "error", err, "pr_id", req.PullRequestID)
+			s.log(r).Error("failed to create PR", "error", err, "pr_id", req.PullRequestID)
 			util.SendError(
 				w,
 				http.StatusInternalServerError,
@@ -148,7 +250,7 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(contentTypeHeader, applicationJSON)
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(PRCreateResponse{PR: *pr}); err != nil {
-		s.Log.Error("failed to encode PR create response", "error", err)
+		s.log(r).Error("failed to encode PR create response", "error", err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -161,7 +263,7 @@ func (s *Services) PRCreateHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Services) PRMergeHandler(w http.ResponseWriter, r *http.Request) {
 	var req PRMergeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode PR merge request", "error", err)
+		s.log(r).Warn("failed to decode PR merge request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -173,7 +275,7 @@ func (s *Services) PRMergeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := validatePRMergeRequest(&req); err != nil {
-		s.Log.Warn("invalid PR merge request", "error", err)
+		s.log(r).Warn("invalid PR merge request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -189,10 +291,10 @@ func (s *Services) PRMergeHandler(w http.ResponseWriter, r *http.Request) {
 	pr, err := s.PRService.MergePR(ctx, req.PullRequestID)
 	if err != nil {
 		if errors.Is(err, entity.ErrNotFound) {
-			s.Log.Warn("PR not found for merge", "pr_id", req.PullRequestID)
+			s.log(r).Warn("PR not found for merge", "pr_id", req.PullRequestID)
 			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "PR not found")
 		} else {
-			s.Log.Error("failed to merge PR", "error", err, "pr_id", req.PullRequestID)
+			s.log(r).Error("failed to merge PR", "error", err, "pr_id", req.PullRequestID)
 			util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
 		}
 
@@ -202,7 +304,7 @@ func (s *Services) PRMergeHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(contentTypeHeader, applicationJSON)
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(PRMergeResponse{PR: *pr}); err != nil {
-		s.Log.Error("failed to encode PR merge response", "error", err)
+		s.log(r).Error("failed to encode PR merge response", "error", err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -216,7 +318,7 @@ func (s *Services) PRMergeHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 	var req PRReassignRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode PR reassign request", "error", err)
+		s.log(r).Warn("failed to decode PR reassign request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -228,7 +330,7 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := validatePRReassignRequest(&req); err != nil {
-		s.Log.Warn("invalid PR reassign request", "error", err)
+		s.log(r).Warn("invalid PR reassign request", "error", err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -239,13 +341,30 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun, err := dryRunParam(r, false)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
 	ctx := r.Context()
 
-	pr, replacedBy, err := s.PRService.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
+	var (
+		pr         *entity.PullRequest
+		replacedBy string
+		report     *entity.ReassignmentReport
+	)
+
+	if dryRun {
+		pr, replacedBy, report, err = s.PRService.PlanReassign(ctx, req.PullRequestID, req.OldUserID)
+	} else {
+		pr, replacedBy, err = s.PRService.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
+	}
+
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrNotFound):
-			s.Log.Warn("PR or user not found for reassign", "pr_id", req.PullRequestID, "old_user_id", req.OldUserID)
+			s.log(r).Warn("PR or user not found for reassign", "pr_id", req.PullRequestID, "old_user_id", req.OldUserID)
 			util.SendError(
 				w,
 				http.StatusNotFound,
@@ -254,7 +373,7 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 			)
 
 		case errors.Is(err, entity.ErrPRMerged):
-			s.Log.Info("attempt to reassign on merged PR", "pr_id", req.PullRequestID)
+			s.log(r).Info("attempt to reassign on merged PR", "pr_id", req.PullRequestID)
 			util.SendError(
 				w,
 				http.StatusConflict,
@@ -263,7 +382,7 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 			)
 
 		case errors.Is(err, entity.ErrNotAssigned):
-			s.Log.Info("reviewer not assigned to PR", "pr_id", req.PullRequestID, "user_id", req.OldUserID)
+			s.log(r).Info("reviewer not assigned to PR", "pr_id", req.PullRequestID, "user_id", req.OldUserID)
 			util.SendError(
 				w,
 				http.StatusConflict,
@@ -272,7 +391,7 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 			)
 
 		case errors.Is(err, entity.ErrNoCandidate):
-			s.Log.Warn("no candidate for PR reassignment", "pr_id", req.PullRequestID)
+			s.log(r).Warn("no candidate for PR reassignment", "pr_id", req.PullRequestID)
 			util.SendError(
 				w,
 				http.StatusConflict,
@@ -281,7 +400,7 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 			)
 
 		default:
-			s.Log.Error("failed to reassign PR reviewer", "error", err, "pr_id", req.PullRequestID)
+			s.log(r).Error("failed to reassign PR reviewer", "error", err, "pr_id", req.PullRequestID)
 			util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
 		}
 
@@ -291,10 +410,11 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(contentTypeHeader, applicationJSON)
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(PRReassignResponse{
-		PR:         *pr,
-		ReplacedBy: replacedBy,
+		PR:           *pr,
+		ReplacedBy:   replacedBy,
+		Reassignment: report,
 	}); err != nil {
-		s.Log.Error("failed to encode PR reassign response", "error", err)
+		s.log(r).Error("failed to encode PR reassign response", "error", err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -303,3 +423,127 @@ func (s *Services) PRReassignHandler(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 }
+
+// PRAddReviewerHandler assigns a given or automatically chosen reviewer to a
+// PR that has a free slot.
+func (s *Services) PRAddReviewerHandler(w http.ResponseWriter, r *http.Request) {
+	var req PRReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode add reviewer request", "error", err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, invalidJSONMsg)
+		return
+	}
+
+	if err := validatePRReviewerRequest(&req, false); err != nil {
+		s.log(r).Warn("invalid add reviewer request", "error", err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	pr, added, err := s.PRService.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		s.sendReviewerError(w, r, err, req.PullRequestID)
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(PRAddReviewerResponse{PR: *pr, Added: added}); err != nil {
+		s.log(r).Error("failed to encode add reviewer response", "error", err)
+	}
+}
+
+// PRRemoveReviewerHandler takes a reviewer off a PR without replacement.
+func (s *Services) PRRemoveReviewerHandler(w http.ResponseWriter, r *http.Request) {
+	var req PRReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode remove reviewer request", "error", err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, invalidJSONMsg)
+		return
+	}
+
+	if err := validatePRReviewerRequest(&req, true); err != nil {
+		s.log(r).Warn("invalid remove reviewer request", "error", err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	pr, err := s.PRService.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		s.sendReviewerError(w, r, err, req.PullRequestID)
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(PRRemoveReviewerResponse{PR: *pr}); err != nil {
+		s.log(r).Error("failed to encode remove reviewer response", "error", err)
+	}
+}
+
+func (s *Services) sendReviewerError(w http.ResponseWriter, r *http.Request, err error, prID string) {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "PR or user not found")
+	case errors.Is(err, entity.ErrPRMerged):
+		util.SendError(w, http.StatusConflict, entity.CodePRMerged, "cannot change reviewers of merged PR")
+	case errors.Is(err, entity.ErrNotAssigned):
+		util.SendError(w, http.StatusConflict, entity.CodeNotAssigned, "reviewer is not assigned to this PR")
+	case errors.Is(err, entity.ErrReviewerLimit):
+		util.SendError(w, http.StatusConflict, entity.CodeReviewerLimit, "PR already has the maximum number of reviewers")
+	case errors.Is(err, entity.ErrNoCandidate):
+		util.SendError(w, http.StatusConflict, entity.CodeNoCandidate, "no active candidate in team")
+	case errors.Is(err, entity.ErrReviewerUnavailable):
+		util.SendError(w, http.StatusConflict, entity.CodeReviewerUnavailable, err.Error())
+	case errors.Is(err, entity.ErrInvalidReviewer):
+		util.SendError(w, http.StatusBadRequest, entity.CodeInvalidReviewer, err.Error())
+	default:
+		s.log(r).Error("failed to change PR reviewers", "error", err, "pr_id", prID)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	s.log(r).Info("PR reviewer change rejected", "error", err, "pr_id", prID)
+}
+
+// PRExplainHandler shows the recorded automatic picks of a PR, identified by
+// pull_request_id or repository_name and number.
+func (s *Services) PRExplainHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	number := Zero
+	if value := query.Get("number"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "number must be an integer")
+			return
+		}
+
+		number = parsed
+	}
+
+	prID := resolvePRID(query.Get("pull_request_id"), query.Get(repositoryNameField), number)
+	if prID == "" {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "pull_request_id is required")
+		return
+	}
+
+	explanation, err := s.PRService.ExplainAssignment(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "PR not found")
+			return
+		}
+
+		s.log(r).Error("failed to explain PR assignment", "error", err, "pr_id", prID)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(explanation); err != nil {
+		s.log(r).Error("failed to encode explain response", "error", err)
+	}
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+const (
+	repositoryNameField = "repository_name"
+)
+
+type RepositoryAddResponse struct {
+	Repository entity.Repository `json:"repository"`
+}
+
+type RepositoryPRListResponse struct {
+	RepositoryName string                    `json:"repository_name"`
+	PullRequests   []entity.PullRequestShort `json:"pull_requests"`
+}
+
+func validateRepositoryAddRequest(repository *entity.Repository) error {
+	if strings.TrimSpace(repository.RepositoryName) == "" {
+		return errors.New("repository_name is required")
+	}
+	if strings.Contains(repository.RepositoryName, "#") {
+		return errors.New("repository_name must not contain '#'")
+	}
+	return nil
+}
+
+func validatePRStatus(status string) error {
+	switch entity.PRStatus(status) {
+	case "", entity.OPEN, entity.MERGED:
+		return nil
+	default:
+		return errors.New("status must be OPEN or MERGED")
+	}
+}
+
+func (s *Services) RepositoryAddHandler(w http.ResponseWriter, r *http.Request) {
+	var req entity.Repository
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode repository add request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			invalidJSONMsg,
+		)
+
+		return
+	}
+
+	if err := validateRepositoryAddRequest(&req); err != nil {
+		s.log(r).Warn("invalid repository add request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error(),
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	repository, err := s.RepositoryService.AddRepository(ctx, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRepositoryExists):
+			s.log(r).Info("attempt to create repository with existing name",
+				repositoryNameField,
+				req.RepositoryName)
+
+			util.SendError(
+				w,
+				http.StatusBadRequest,
+				entity.CodeRepositoryExists,
+				"repository_name already exists",
+			)
+
+		case errors.Is(err, entity.ErrNotFound):
+			s.log(r).Warn("default team not found for repository",
+				repositoryNameField, req.RepositoryName,
+				teamNameField, req.DefaultTeam)
+
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"default team not found",
+			)
+
+		default:
+			s.log(r).Error("failed to add repository",
+				errFieldName, err,
+				repositoryNameField, req.RepositoryName)
+
+			util.SendError(
+				w,
+				http.StatusInternalServerError,
+				entity.CodeInternalError,
+				"internal server error",
+			)
+		}
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(RepositoryAddResponse{Repository: *repository}); err != nil {
+		s.log(r).Error("failed to encode repository add response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
+
+func (s *Services) RepositoryGetHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get(repositoryNameField)
+	if strings.TrimSpace(name) == "" {
+		s.log(r).Warn("invalid repository get request")
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"repository_name is required",
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	repository, err := s.RepositoryService.GetRepository(ctx, name)
+	if err != nil {
+		s.log(r).Warn("repository not found", repositoryNameField, name)
+		util.SendError(
+			w,
+			http.StatusNotFound,
+			entity.CodeNotFound,
+			"repository not found",
+		)
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(repository); err != nil {
+		s.log(r).Error("failed to encode repository get response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
+
+func (s *Services) RepositoryPRListHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get(repositoryNameField)
+	status := r.URL.Query().Get("status")
+
+	if strings.TrimSpace(name) == "" {
+		s.log(r).Warn("invalid repository PR list request")
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"repository_name is required",
+		)
+
+		return
+	}
+
+	if err := validatePRStatus(status); err != nil {
+		s.log(r).Warn("invalid repository PR list request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error(),
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	prs, err := s.RepositoryService.ListPullRequests(ctx, name, entity.PRStatus(status))
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("repository not found for PR list", repositoryNameField, name)
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"repository not found",
+			)
+
+			return
+		}
+
+		s.log(r).Error("failed to list repository PRs",
+			errFieldName, err,
+			repositoryNameField, name)
+
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error",
+		)
+
+		return
+	}
+
+	pullRequests := make([]entity.PullRequestShort, len(prs))
+	for i, pr := range prs {
+		pullRequests[i] = *pr
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(RepositoryPRListResponse{
+		RepositoryName: name,
+		PullRequests:   pullRequests,
+	}); err != nil {
+		s.log(r).Error("failed to encode repository PR list response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
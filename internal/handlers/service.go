@@ -2,24 +2,59 @@ package handlers
 
 import (
 	"log/slog"
+	"net/http"
 
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	//nolint:revive // dependency
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/service"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/logger"
 )
 
+// ServiceOptions carries settings from config.Config into the services.
+type ServiceOptions struct {
+	SLAAction entity.SLAAction
+	PR        []service.PROption
+}
+
 type Services struct {
-	Log          *slog.Logger
-	TeamService  TeamServiceInterface
-	UserService  UserServiceInterface
-	PRService    PRServiceInterface
-	LoadService  LoadServiceInterface
-	StatsService StatsServiceInterface
+	Log                 *slog.Logger
+	TeamService         TeamServiceInterface
+	UserService         UserServiceInterface
+	PRService           PRServiceInterface
+	RepositoryService   RepositoryServiceInterface
+	AvailabilityService AvailabilityServiceInterface
+	SLAService          SLAServiceInterface
+	HistoryService      HistoryServiceInterface
+	ImportService       ImportServiceInterface
+	LoadService         LoadServiceInterface
+	StatsService        StatsServiceInterface
+	ConflictService     ConflictServiceInterface
+}
+
+// log returns the request-scoped logger set up by logger.Middleware, so
+// handler records carry the request ID.
+func (s *Services) log(r *http.Request) *slog.Logger {
+	return logger.FromContext(r.Context(), s.Log)
 }
 
 //nolint:revive // long line
-func CreateNewService(repo *postgres.Repository, logger *slog.Logger) *Services {
-	prService := service.NewPRService(repo.PullRequests, repo.Users, repo.Teams)
+func CreateNewService(
+	repo *postgres.Repository,
+	logger *slog.Logger,
+	opts ServiceOptions,
+) *Services {
+	prService := service.NewPRService(
+		repo.PullRequests,
+		repo.Users,
+		repo.Teams,
+		append([]service.PROption{
+			service.WithRepositories(repo.Repositories),
+			service.WithTransactor(repo.Tx),
+			service.WithConflicts(repo.Conflicts),
+			service.WithDecisions(repo.Decisions),
+		}, opts.PR...)...,
+	)
 
 	return &Services{
 		Log:         logger,
@@ -30,8 +65,31 @@ func CreateNewService(repo *postgres.Repository, logger *slog.Logger) *Services
 			repo.Teams,
 			prService,
 		),
-		PRService:    prService,
-		LoadService:  &service.LoadService{},
-		StatsService: service.NewStatsService(repo.Stats),
+		PRService: prService,
+		RepositoryService: service.NewRepositoryService(
+			repo.Repositories,
+			repo.PullRequests,
+			repo.Teams,
+		),
+		AvailabilityService: service.NewAvailabilityService(
+			repo.Availability,
+			repo.Users,
+			repo.PullRequests,
+			prService,
+		),
+		SLAService: service.NewSLAService(
+			repo.SLA,
+			repo.PullRequests,
+			prService,
+			opts.SLAAction,
+		),
+		HistoryService: service.NewHistoryService(repo.Snapshots),
+		ImportService:  service.NewImportService(repo.Teams, repo.Users),
+		LoadService:    &service.LoadService{},
+		StatsService:   service.NewStatsService(repo.Stats),
+		ConflictService: service.NewConflictService(
+			repo.Conflicts,
+			repo.Users,
+		),
 	}
 }
@@ -12,7 +12,7 @@ import (
 type PRServiceInterface interface {
 	CreatePR(
 		ctx context.Context,
-		prID, prName, authorID string,
+		params entity.PRCreateParams,
 	) (
 		*entity.PullRequest,
 		string,
@@ -27,6 +27,25 @@ type PRServiceInterface interface {
 		string,
 		error,
 	)
+	AddReviewer(
+		ctx context.Context,
+		prID, userID string,
+	) (
+		*entity.PullRequest,
+		string,
+		error,
+	)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*entity.PullRequest, error)
+	ExplainAssignment(ctx context.Context, prID string) (*entity.AssignmentExplanation, error)
+	PlanReassign(
+		ctx context.Context,
+		prID, oldReviewerID string,
+	) (
+		*entity.PullRequest,
+		string,
+		*entity.ReassignmentReport,
+		error,
+	)
 }
 
 type UserServiceInterface interface {
@@ -34,7 +53,7 @@ type UserServiceInterface interface {
 		ctx context.Context,
 		userID string,
 		isActive bool,
-	) (*entity.User, error)
+	) (*entity.User, *entity.ReassignmentReport, error)
 	GetPRsAssignedTo(
 		ctx context.Context,
 		userID string,
@@ -43,12 +62,105 @@ type UserServiceInterface interface {
 		[]*entity.PullRequestShort,
 		error,
 	)
-	MassDeactivate(ctx context.Context, users []entity.User, flag bool) error
+	PlanStatusChange(
+		ctx context.Context,
+		userID string,
+		isActive bool,
+	) (*entity.User, *entity.ReassignmentReport, error)
+	MassDeactivate(ctx context.Context, users []entity.User, flag bool) (*entity.ReassignmentReport, error)
+	PlanMassDeactivate(ctx context.Context, users []entity.User) (*entity.ReassignmentReport, error)
+	Handover(
+		ctx context.Context,
+		fromUserID string,
+		delegateIDs []string,
+		dryRun bool,
+	) (*entity.ReassignmentReport, error)
+	BulkSetIsActive(
+		ctx context.Context,
+		changes []entity.StatusChange,
+	) *entity.BulkStatusReport
+	GetReviewCapacity(
+		ctx context.Context,
+		userID string,
+	) (*entity.ReviewCapacity, error)
+	SetMaxOpenReviews(
+		ctx context.Context,
+		userID string,
+		maxOpenReviews *int,
+	) (*entity.User, error)
+	SetWorkingHours(
+		ctx context.Context,
+		userID, timeZone, workStart, workEnd string,
+	) (*entity.User, error)
 }
 
 type TeamServiceInterface interface {
 	AddTeam(ctx context.Context, team *entity.Team) (*entity.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	SetReviewSLA(
+		ctx context.Context,
+		teamName string,
+		slaMinutes *int,
+		leadUserID string,
+	) (*entity.Team, error)
+}
+
+type RepositoryServiceInterface interface {
+	AddRepository(
+		ctx context.Context,
+		repository *entity.Repository,
+	) (*entity.Repository, error)
+	GetRepository(ctx context.Context, name string) (*entity.Repository, error)
+	ListPullRequests(
+		ctx context.Context,
+		name string,
+		status entity.PRStatus,
+	) ([]*entity.PullRequestShort, error)
+}
+
+type AvailabilityServiceInterface interface {
+	AddUnavailability(
+		ctx context.Context,
+		window *entity.Unavailability,
+	) (*entity.Unavailability, error)
+	ListUnavailability(
+		ctx context.Context,
+		userID string,
+	) ([]*entity.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	ProcessStartedWindows(ctx context.Context) (int, error)
+}
+
+type ConflictServiceInterface interface {
+	AddConflict(
+		ctx context.Context,
+		conflict *entity.ReviewerConflict,
+	) (*entity.ReviewerConflict, error)
+	DeleteConflict(ctx context.Context, userID, otherUserID string) error
+	ListConflicts(
+		ctx context.Context,
+		userID string,
+	) ([]*entity.ReviewerConflict, error)
+}
+
+type SLAServiceInterface interface {
+	GetOverdue(ctx context.Context) (*entity.OverdueReport, error)
+	CheckOverdue(ctx context.Context) (int, error)
+}
+
+type HistoryServiceInterface interface {
+	TakeSnapshot(ctx context.Context) error
+	GetHistory(
+		ctx context.Context,
+		metric entity.StatsMetric,
+		subject string,
+		from, to time.Time,
+	) (*entity.StatsHistory, error)
+}
+
+type ImportServiceInterface interface {
+	Import(ctx context.Context, teams []entity.Team, dryRun bool) (*entity.ImportReport, error)
+	Export(ctx context.Context) ([]entity.Team, error)
 }
 
 type LoadServiceInterface interface {
@@ -57,5 +169,20 @@ type LoadServiceInterface interface {
 
 type StatsServiceInterface interface {
 	GetAssignedCountPerPR(ctx context.Context) (map[string]int, error)
+	GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error)
 	GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error)
+	GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error)
+	GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error)
+	GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error)
+	GetTurnaround(
+		ctx context.Context,
+		from, to time.Time,
+		groupBy entity.TurnaroundGroupBy,
+	) (*entity.TurnaroundReport, error)
+	GetFairness(
+		ctx context.Context,
+		teamName string,
+		from, to time.Time,
+		threshold float64,
+	) (*entity.FairnessReport, error)
 }
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,68 +17,103 @@ import (
 
 const (
 	errFieldName = "error"
+
+	// maxReviewersLabel groups PRs with this many reviewers or more.
+	maxReviewersLabel = 3
 )
 
+// The gauges below are refreshed by UpdateMetrics from a background job;
+// scrapes only read the cached values. Labels are teams, repositories and a
+// small reviewer count, never individual PRs or users.
 var (
-	assignedReviewersGauge = prometheus.NewGaugeVec(
+	openPRsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
-			Name: "assigned_reviewers_per_pr",
-			Help: "Number of assigned reviewers for a given open pull request",
+			Name: "open_pull_requests",
+			Help: "Number of open pull requests",
 		},
-		[]string{"pull_request_id"},
 	)
 
-	openPRsPerUserGauge = prometheus.NewGaugeVec(
+	openPRsByReviewerCountGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "open_prs_per_user",
-			Help: "Number of open pull requests currently assigned to a user",
+			Name: "open_prs_by_reviewer_count",
+			Help: "Number of open pull requests by how many reviewers they have",
 		},
-		[]string{"user_id"},
+		[]string{"reviewers"},
 	)
-)
 
-var metricsRegistry = prometheus.NewRegistry()
+	openPRsPerRepositoryGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "open_prs_per_repository",
+			Help: "Number of open pull requests in a repository",
+		},
+		[]string{"repository_name"},
+	)
 
-func init() {
-	metricsRegistry.MustRegister(assignedReviewersGauge, openPRsPerUserGauge)
-}
+	openPRsPerTeamGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "open_prs_per_team",
+			Help: "Number of open pull requests authored by team members",
+		},
+		[]string{"team_name"},
+	)
 
-func (s *Services) MetricsHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	openReviewsPerTeamGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "open_reviews_per_team",
+			Help: "Number of open reviews assigned to team members",
+		},
+		[]string{"team_name"},
+	)
 
-	prCounts, err := s.StatsService.GetAssignedCountPerPR(ctx)
-	if err != nil {
-		s.Log.Error("failed to get PR counts", "error", err)
-		http.Error(w, "failed to get PR counts", http.StatusInternalServerError)
-		return
-	}
+	maxMemberReviewsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "max_open_reviews_per_member",
+			Help: "Highest number of open reviews held by a single team member",
+		},
+		[]string{"team_name"},
+	)
 
-	userCounts, err := s.StatsService.GetOpenPRCountPerUser(ctx)
-	if err != nil {
-		s.Log.Error("failed to get user counts", "error", err)
-		util.SendError(w,
-			http.StatusInternalServerError,
-			entity.CodeInternalError,
-			"failed to get user counts")
-		return
-	}
+	activeMembersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "active_members_per_team",
+			Help: "Number of active team members",
+		},
+		[]string{"team_name"},
+	)
 
-	assignedReviewersGauge.Reset()
-	openPRsPerUserGauge.Reset()
+	overdueReviewsPerTeamGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "overdue_reviews_per_team",
+			Help: "Number of open reviews exceeding the team review SLA",
+		},
+		[]string{"team_name"},
+	)
+)
 
-	for prID, cnt := range prCounts {
-		assignedReviewersGauge.WithLabelValues(prID).Set(float64(cnt))
-	}
+func init() {
+	metrics.Registry.MustRegister(
+		openPRsGauge,
+		openPRsByReviewerCountGauge,
+		openPRsPerRepositoryGauge,
+		openPRsPerTeamGauge,
+		openReviewsPerTeamGauge,
+		maxMemberReviewsGauge,
+		activeMembersGauge,
+		overdueReviewsPerTeamGauge,
+	)
+}
 
-	for userID, cnt := range userCounts {
-		openPRsPerUserGauge.WithLabelValues(userID).Set(float64(cnt))
-	}
+var metricsHTTPHandler = promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
 
-	promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+// MetricsHandler serves the cached metrics without touching the database.
+func (s *Services) MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	metricsHTTPHandler.ServeHTTP(w, r)
 }
 
+// UpdateMetrics recomputes the aggregated gauges; it is run periodically by
+// the metrics collector job.
 func (s *Services) UpdateMetrics(ctx context.Context) error {
-	prCounts, err := s.StatsService.GetAssignedCountPerPR(ctx)
+	byReviewerCount, err := s.StatsService.GetOpenPRCountByReviewerCount(ctx)
 	if err != nil {
 		s.Log.Error("failed to get PR counts in UpdateMetrics",
 			errFieldName,
@@ -84,25 +122,109 @@ func (s *Services) UpdateMetrics(ctx context.Context) error {
 		return err
 	}
 
-	userCounts, err := s.StatsService.GetOpenPRCountPerUser(ctx)
+	repositoryCounts, err := s.StatsService.GetOpenPRCountPerRepository(ctx)
 	if err != nil {
-		s.Log.Error("failed to get user counts in UpdateMetrics",
+		s.Log.Error("failed to get repository counts in UpdateMetrics",
 			errFieldName,
 			err)
 
 		return err
 	}
 
-	assignedReviewersGauge.Reset()
-	openPRsPerUserGauge.Reset()
+	teamLoads, err := s.StatsService.GetTeamLoad(ctx)
+	if err != nil {
+		s.Log.Error("failed to get team load in UpdateMetrics",
+			errFieldName,
+			err)
 
-	for prID, cnt := range prCounts {
-		assignedReviewersGauge.WithLabelValues(prID).Set(float64(cnt))
+		return err
 	}
 
-	for userID, cnt := range userCounts {
-		openPRsPerUserGauge.WithLabelValues(userID).Set(float64(cnt))
+	overdueCounts, err := s.StatsService.GetOverdueCountPerTeam(ctx)
+	if err != nil {
+		s.Log.Error("failed to get overdue counts in UpdateMetrics",
+			errFieldName,
+			err)
+
+		return err
 	}
 
+	setStatsGauges(byReviewerCount, repositoryCounts, overdueCounts, teamLoads)
+
 	return nil
 }
+
+func setStatsGauges(
+	byReviewerCount map[int]int,
+	repositoryCounts, overdueCounts map[string]int,
+	teamLoads []*entity.TeamLoad,
+) {
+	openPRsByReviewerCountGauge.Reset()
+	openPRsPerRepositoryGauge.Reset()
+	openPRsPerTeamGauge.Reset()
+	openReviewsPerTeamGauge.Reset()
+	maxMemberReviewsGauge.Reset()
+	activeMembersGauge.Reset()
+	overdueReviewsPerTeamGauge.Reset()
+
+	byReviewers := make(map[string]int, maxReviewersLabel+1)
+	for reviewers := range maxReviewersLabel + 1 {
+		byReviewers[reviewersLabel(reviewers)] = 0
+	}
+
+	openPRs := 0
+	for reviewers, cnt := range byReviewerCount {
+		byReviewers[reviewersLabel(reviewers)] += cnt
+		openPRs += cnt
+	}
+
+	openPRsGauge.Set(float64(openPRs))
+
+	for label, cnt := range byReviewers {
+		openPRsByReviewerCountGauge.WithLabelValues(label).Set(float64(cnt))
+	}
+
+	for repositoryName, cnt := range repositoryCounts {
+		openPRsPerRepositoryGauge.WithLabelValues(repositoryName).Set(float64(cnt))
+	}
+
+	for _, load := range teamLoads {
+		openPRsPerTeamGauge.WithLabelValues(load.TeamName).Set(float64(load.OpenPRs))
+		openReviewsPerTeamGauge.WithLabelValues(load.TeamName).Set(float64(load.OpenReviews))
+		maxMemberReviewsGauge.WithLabelValues(load.TeamName).Set(float64(load.MaxMemberReviews))
+		activeMembersGauge.WithLabelValues(load.TeamName).Set(float64(load.ActiveMembers))
+	}
+
+	for teamName, cnt := range overdueCounts {
+		overdueReviewsPerTeamGauge.WithLabelValues(teamName).Set(float64(cnt))
+	}
+}
+
+func reviewersLabel(cnt int) string {
+	if cnt >= maxReviewersLabel {
+		return strconv.Itoa(maxReviewersLabel) + "+"
+	}
+
+	return strconv.Itoa(cnt)
+}
+
+func (s *Services) OverdueReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := s.SLAService.GetOverdue(ctx)
+	if err != nil {
+		s.log(r).Error("failed to get overdue reviews", errFieldName, err)
+		util.SendError(w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error")
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode overdue reviews response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
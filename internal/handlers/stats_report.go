@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/service"
+)
+
+const defaultStatsPeriod = 30 * 24 * time.Hour
+
+// parseStatsTime accepts RFC 3339 timestamps and plain dates (UTC midnight).
+func parseStatsTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
+
+// parseStatsPeriod reads the from/to query parameters; the period defaults to
+// the last 30 days.
+func parseStatsPeriod(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if value := r.URL.Query().Get("to"); value != "" {
+		t, err := parseStatsTime(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date or RFC 3339 timestamp")
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultStatsPeriod)
+	if value := r.URL.Query().Get("from"); value != "" {
+		t, err := parseStatsTime(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date or RFC 3339 timestamp")
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
+
+func (s *Services) TurnaroundHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseStatsPeriod(r)
+	if err != nil {
+		s.log(r).Warn("invalid turnaround request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	groupBy := entity.TurnaroundGroupBy(r.URL.Query().Get("group_by"))
+	switch groupBy {
+	case "":
+		groupBy = entity.GroupByTeam
+	case entity.GroupByTeam, entity.GroupByUser:
+	default:
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "group_by must be team or user")
+		return
+	}
+
+	report, err := s.StatsService.GetTurnaround(r.Context(), from, to, groupBy)
+	if err != nil {
+		s.log(r).Error("failed to get turnaround", errFieldName, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode turnaround response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
+
+func (s *Services) StatsHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseStatsPeriod(r)
+	if err != nil {
+		s.log(r).Warn("invalid stats history request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	metric := entity.StatsMetric(r.URL.Query().Get("metric"))
+	if !slices.Contains(entity.StatsMetrics, metric) {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "unknown metric")
+		return
+	}
+
+	history, err := s.HistoryService.GetHistory(r.Context(), metric, r.URL.Query().Get("subject"), from, to)
+	if err != nil {
+		s.log(r).Error("failed to get stats history", errFieldName, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		s.log(r).Error("failed to encode stats history response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
+
+func (s *Services) FairnessHandler(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team")
+	if err := validateTeamName(teamName); err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "team is required")
+		return
+	}
+
+	from, to, err := parseStatsPeriod(r)
+	if err != nil {
+		s.log(r).Warn("invalid fairness request", ERROR, err)
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	threshold := service.DefaultFairnessThreshold
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		threshold, err = strconv.ParseFloat(value, 64)
+		if err != nil || threshold <= Zero {
+			util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, "threshold must be a positive number")
+			return
+		}
+	}
+
+	report, err := s.StatsService.GetFairness(r.Context(), teamName, from, to, threshold)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			util.SendError(w, http.StatusNotFound, entity.CodeNotFound, "team not found")
+			return
+		}
+
+		s.log(r).Error("failed to get fairness report", errFieldName, err, teamNameField, teamName)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "internal server error")
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode fairness response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, encodeErrorMsg)
+	}
+}
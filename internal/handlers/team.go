@@ -20,10 +20,19 @@ type TeamAddResponse struct {
 	Team entity.Team `json:"team"`
 }
 
+type TeamSetReviewSLARequest struct {
+	ReviewSLAMinutes *int   `json:"review_sla_minutes"`
+	TeamName         string `json:"team_name"`
+	LeadUserID       string `json:"lead_user_id"`
+}
+
 func validateTeamAddRequest(team *entity.Team) error {
 	if strings.TrimSpace(team.TeamName) == "" {
 		return errors.New("team_name is required")
 	}
+	if team.ReviewSLAMinutes != nil && *team.ReviewSLAMinutes <= Zero {
+		return errors.New("review_sla_minutes must be positive")
+	}
 	return nil
 }
 
@@ -37,7 +46,7 @@ func validateTeamName(name string) error {
 func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 	var req entity.Team
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode team add request", ERROR, err)
+		s.log(r).Warn("failed to decode team add request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -49,7 +58,7 @@ func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := validateTeamAddRequest(&req); err != nil {
-		s.Log.Warn("invalid team add request", ERROR, err)
+		s.log(r).Warn("invalid team add request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -65,7 +74,7 @@ func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 	team, err := s.TeamService.AddTeam(ctx, &req)
 	if err != nil {
 		if errors.Is(err, entity.ErrTeamExists) {
-			s.Log.Info("attempt to create team with existing name",
+			s.log(r).Info("attempt to create team with existing name",
 				teamNameField,
 				req.TeamName)
 
@@ -79,7 +88,18 @@ func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		s.Log.Error("failed to add team",
+		if errors.Is(err, entity.ErrLeadNotMember) {
+			util.SendError(
+				w,
+				http.StatusBadRequest,
+				entity.CodeLeadNotMember,
+				"lead_user_id must be a team member",
+			)
+
+			return
+		}
+
+		s.log(r).Error("failed to add team",
 			errFieldName, err,
 			teamNameField,
 			req.TeamName)
@@ -98,7 +118,7 @@ func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(TeamAddResponse{Team: *team})
 	if err != nil {
-		s.Log.Error("failed to encode team add response", ERROR, err)
+		s.log(r).Error("failed to encode team add response", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -111,7 +131,7 @@ func (s *Services) TeamAddHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Services) TeamGetHandler(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get(teamNameField)
 	if err := validateTeamName(name); err != nil {
-		s.Log.Warn("invalid team get request", ERROR, err)
+		s.log(r).Warn("invalid team get request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -126,7 +146,7 @@ func (s *Services) TeamGetHandler(w http.ResponseWriter, r *http.Request) {
 
 	team, err := s.TeamService.GetTeam(ctx, name)
 	if err != nil {
-		s.Log.Warn("team not found", "team_name", name)
+		s.log(r).Warn("team not found", "team_name", name)
 		util.SendError(
 			w,
 			http.StatusNotFound,
@@ -140,7 +160,7 @@ func (s *Services) TeamGetHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(team); err != nil {
-		s.Log.Error("failed to encode team get response", ERROR, err)
+		s.log(r).Error("failed to encode team get response", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -149,3 +169,88 @@ func (s *Services) TeamGetHandler(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 }
+
+func (s *Services) TeamSetReviewSLAHandler(w http.ResponseWriter, r *http.Request) {
+	var req TeamSetReviewSLARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode team set review sla request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			invalidJSONMsg,
+		)
+
+		return
+	}
+
+	if err := validateTeamName(req.TeamName); err != nil {
+		s.log(r).Warn("invalid team set review sla request", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error(),
+		)
+
+		return
+	}
+
+	if req.ReviewSLAMinutes != nil && *req.ReviewSLAMinutes <= Zero {
+		util.SendError(
+			w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"review_sla_minutes must be positive",
+		)
+
+		return
+	}
+
+	ctx := r.Context()
+
+	team, err := s.TeamService.SetReviewSLA(ctx, req.TeamName, req.ReviewSLAMinutes, req.LeadUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			s.log(r).Warn("team not found for review sla change", teamNameField, req.TeamName)
+			util.SendError(
+				w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"team not found",
+			)
+		case errors.Is(err, entity.ErrLeadNotMember):
+			util.SendError(
+				w,
+				http.StatusBadRequest,
+				entity.CodeLeadNotMember,
+				"lead_user_id must be a team member",
+			)
+		default:
+			s.log(r).Error("failed to set review sla",
+				errFieldName, err,
+				teamNameField, req.TeamName)
+			util.SendError(
+				w,
+				http.StatusInternalServerError,
+				entity.CodeInternalError,
+				"internal server error",
+			)
+		}
+
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(TeamAddResponse{Team: *team}); err != nil {
+		s.log(r).Error("failed to encode team set review sla response", ERROR, err)
+		util.SendError(
+			w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			encodeErrorMsg,
+		)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
 
@@ -14,6 +15,8 @@ import (
 const (
 	Zero        = 0
 	userIDField = "user_id"
+	// maxBulkUsers bounds one bulk request; each user is processed in turn.
+	maxBulkUsers = 1000
 )
 
 type UserSetIsActiveRequest struct {
@@ -22,21 +25,43 @@ type UserSetIsActiveRequest struct {
 }
 
 type UserSetIsActiveResponse struct {
-	User entity.User `json:"user"`
+	Reassignment *entity.ReassignmentReport `json:"reassignment,omitempty"`
+	User         entity.User                `json:"user"`
 }
 
 type UserGetReviewResponse struct {
+	Capacity     *entity.ReviewCapacity    `json:"capacity,omitempty"`
 	UserID       string                    `json:"user_id"`
 	PullRequests []entity.PullRequestShort `json:"pull_requests"`
 }
 
+type UserSetMaxOpenReviewsRequest struct {
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	UserID         string `json:"user_id"`
+}
+
+type UserSetWorkingHoursRequest struct {
+	UserID    string `json:"user_id"`
+	TimeZone  string `json:"time_zone"`
+	WorkStart string `json:"work_start"`
+	WorkEnd   string `json:"work_end"`
+}
+
 type UserMassChangeRequest struct {
 	Users []entity.UserItem `json:"users"`
 	Flag  bool              `json:"flag"`
 }
 
-type UserMassChangeResponse struct {
-	Deactivated []string `json:"deactivated_user_ids"`
+// UserHandoverRequest names either one delegate in to_user_id or several in
+// delegates.
+type UserHandoverRequest struct {
+	FromUserID string   `json:"from_user_id"`
+	ToUserID   string   `json:"to_user_id"`
+	Delegates  []string `json:"delegates"`
+}
+
+type UserBulkSetIsActiveRequest struct {
+	Users []entity.StatusChange `json:"users"`
 }
 
 func validateUserSetIsActiveRequest(req *UserSetIsActiveRequest) error {
@@ -53,13 +78,94 @@ func validateUserID(userID string) error {
 	return nil
 }
 
+func validateUserBulkSetIsActiveRequest(req *UserBulkSetIsActiveRequest) error {
+	if len(req.Users) > maxBulkUsers {
+		return errors.New("too many users in one request")
+	}
+
+	seen := make(map[string]bool, len(req.Users))
+	for _, change := range req.Users {
+		if err := validateUserID(change.UserID); err != nil {
+			return err
+		}
+
+		if seen[change.UserID] {
+			return errors.New("duplicate user_id " + change.UserID)
+		}
+		seen[change.UserID] = true
+	}
+
+	return nil
+}
+
+func validateUserHandoverRequest(req *UserHandoverRequest) error {
+	if strings.TrimSpace(req.FromUserID) == "" {
+		return errors.New("from_user_id is required")
+	}
+
+	switch {
+	case req.ToUserID != "" && len(req.Delegates) > Zero:
+		return errors.New("set either to_user_id or delegates")
+	case req.ToUserID != "":
+		req.Delegates = []string{req.ToUserID}
+	case len(req.Delegates) == Zero:
+		return errors.New("to_user_id or delegates is required")
+	}
+
+	if len(req.Delegates) > maxBulkUsers {
+		return errors.New("too many delegates in one request")
+	}
+
+	for _, id := range req.Delegates {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("delegate user_id must not be empty")
+		}
+	}
+
+	return nil
+}
+
+func validateUserSetWorkingHoursRequest(req *UserSetWorkingHoursRequest) error {
+	if err := validateUserID(req.UserID); err != nil {
+		return err
+	}
+
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		return errors.New("unknown time_zone")
+	}
+
+	if (req.WorkStart == "") != (req.WorkEnd == "") {
+		return errors.New("work_start and work_end must be set together")
+	}
+
+	for _, bound := range []string{req.WorkStart, req.WorkEnd} {
+		if bound == "" {
+			continue
+		}
+
+		if _, err := time.Parse(entity.WorkingHoursLayout, bound); err != nil {
+			return errors.New("working hours must be in HH:MM format")
+		}
+	}
+
+	if req.WorkStart != "" && req.WorkStart == req.WorkEnd {
+		return errors.New("work_start and work_end must differ")
+	}
+
+	return nil
+}
+
 func (s *Services) UserSetIsActiveHandler(
 	w http.ResponseWriter,
 	r *http.Request,
 ) {
 	var req UserSetIsActiveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode user set active request", ERROR, err)
+		s.log(r).Warn("failed to decode user set active request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -71,7 +177,7 @@ func (s *Services) UserSetIsActiveHandler(
 	}
 
 	if err := validateUserSetIsActiveRequest(&req); err != nil {
-		s.Log.Warn("invalid user set active request", ERROR, err)
+		s.log(r).Warn("invalid user set active request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -82,12 +188,28 @@ func (s *Services) UserSetIsActiveHandler(
 		return
 	}
 
+	dryRun, err := dryRunParam(r, false)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
 	ctx := r.Context()
 
-	user, err := s.UserService.ChangeStatus(ctx, req.UserID, req.IsActive)
+	var (
+		user   *entity.User
+		report *entity.ReassignmentReport
+	)
+
+	if dryRun {
+		user, report, err = s.UserService.PlanStatusChange(ctx, req.UserID, req.IsActive)
+	} else {
+		user, report, err = s.UserService.ChangeStatus(ctx, req.UserID, req.IsActive)
+	}
+
 	if err != nil {
 		if errors.Is(err, entity.ErrNotFound) {
-			s.Log.Warn("user not found for status change",
+			s.log(r).Warn("user not found for status change",
 				userIDField,
 				req.UserID)
 
@@ -101,7 +223,7 @@ func (s *Services) UserSetIsActiveHandler(
 			return
 		}
 
-		s.Log.Error("failed to change user status",
+		s.log(r).Error("failed to change user status",
 			errFieldName,
 			err,
 			userIDField,
@@ -119,9 +241,9 @@ func (s *Services) UserSetIsActiveHandler(
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(
-		UserSetIsActiveResponse{User: *user},
+		UserSetIsActiveResponse{User: *user, Reassignment: report},
 	); err != nil {
-		s.Log.Error("failed to encode user set active response", ERROR, err)
+		s.log(r).Error("failed to encode user set active response", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusInternalServerError,
@@ -137,7 +259,7 @@ func (s *Services) UserGetReviewHandler(
 ) {
 	userID := r.URL.Query().Get(userIDField)
 	if err := validateUserID(userID); err != nil {
-		s.Log.Warn("invalid user get review request", ERROR, err)
+		s.log(r).Warn("invalid user get review request", ERROR, err)
 		util.SendError(
 			w,
 			http.StatusBadRequest,
@@ -153,7 +275,7 @@ func (s *Services) UserGetReviewHandler(
 	id, prs, err := s.UserService.GetPRsAssignedTo(ctx, userID)
 	if err != nil {
 		if errors.Is(err, entity.ErrNotFound) {
-			s.Log.Warn("user not found for PR retrieval", "user_id", userID)
+			s.log(r).Warn("user not found for PR retrieval", "user_id", userID)
 			util.SendError(
 				w,
 				http.StatusNotFound,
@@ -164,7 +286,24 @@ func (s *Services) UserGetReviewHandler(
 			return
 		}
 
-		s.Log.Error("failed to get PRs for user",
+		s.log(r).Error("failed to get PRs for user",
+			errFieldName,
+			err,
+			userIDField,
+			userID)
+
+		util.SendError(w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error",
+		)
+
+		return
+	}
+
+	capacity, err := s.UserService.GetReviewCapacity(ctx, userID)
+	if err != nil {
+		s.log(r).Error("failed to get review capacity for user",
 			errFieldName,
 			err,
 			userIDField,
@@ -187,12 +326,13 @@ func (s *Services) UserGetReviewHandler(
 	resp := UserGetReviewResponse{
 		UserID:       id,
 		PullRequests: pullRequests,
+		Capacity:     capacity,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		s.Log.Error("failed to encode user get review response", ERROR, err)
+		s.log(r).Error("failed to encode user get review response", ERROR, err)
 		util.SendError(w,
 			http.StatusInternalServerError,
 			entity.CodeInternalError,
@@ -207,7 +347,7 @@ func (s *Services) UsersMassDeactivateHandler(
 ) {
 	var req UserMassChangeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		s.Log.Warn("failed to decode mass deactivate request", ERROR, err)
+		s.log(r).Warn("failed to decode mass deactivate request", ERROR, err)
 		util.SendError(w,
 			http.StatusBadRequest,
 			entity.CodeBadRequest,
@@ -216,7 +356,7 @@ func (s *Services) UsersMassDeactivateHandler(
 	}
 
 	if len(req.Users) == Zero {
-		s.Log.Warn("empty users list in mass deactivate request")
+		s.log(r).Warn("empty users list in mass deactivate request")
 		util.SendError(w,
 			http.StatusBadRequest,
 			entity.CodeEmptyRequest,
@@ -225,7 +365,7 @@ func (s *Services) UsersMassDeactivateHandler(
 	}
 
 	if req.Flag {
-		s.Log.Warn("invalid flag for mass deactivate (must be false)")
+		s.log(r).Warn("invalid flag for mass deactivate (must be false)")
 		util.SendError(w,
 			http.StatusBadRequest,
 			entity.CodeOnlyDeactivate,
@@ -233,44 +373,63 @@ func (s *Services) UsersMassDeactivateHandler(
 		return
 	}
 
+	dryRun, err := dryRunParam(r, false)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
 	users := make([]entity.User, 0, len(req.Users))
 	for _, u := range req.Users {
-		users = append(users, entity.User(u))
+		users = append(users, entity.User{
+			UserID:   u.UserID,
+			Username: u.Username,
+			TeamName: u.TeamName,
+			IsActive: u.IsActive,
+		})
 	}
 
 	ctx := r.Context()
-	if err := s.UserService.MassDeactivate(ctx, users, req.Flag); err != nil {
+
+	var report *entity.ReassignmentReport
+	if dryRun {
+		report, err = s.UserService.PlanMassDeactivate(ctx, users)
+	} else {
+		report, err = s.UserService.MassDeactivate(ctx, users, req.Flag)
+	}
+
+	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrNotFound):
-			s.Log.Warn("user not found during mass deactivate")
+			s.log(r).Warn("user not found during mass deactivate")
 			util.SendError(w,
 				http.StatusNotFound,
 				entity.CodeNotFound,
 				"user not found")
 			return
-		case errors.Is(err, entity.ErrUsersFromDifferentTeams):
-			s.Log.Warn("users from different teams in mass deactivate")
+		case errors.Is(err, entity.ErrTeamMismatch):
+			s.log(r).Warn("team mismatch in mass deactivate", ERROR, err)
 			util.SendError(w,
 				http.StatusBadRequest,
-				entity.CodeUsersFromDifferentTeams,
-				"users belong to different teams")
+				entity.CodeTeamMismatch,
+				err.Error())
 			return
 		case errors.Is(err, entity.ErrEmptyRequest):
-			s.Log.Warn("empty request in mass deactivate")
+			s.log(r).Warn("empty request in mass deactivate")
 			util.SendError(w,
 				http.StatusBadRequest,
 				entity.CodeEmptyRequest,
 				"empty request")
 			return
 		case errors.Is(err, entity.ErrOnlyDeactivate):
-			s.Log.Warn("only deactivation supported")
+			s.log(r).Warn("only deactivation supported")
 			util.SendError(w,
 				http.StatusBadRequest,
 				entity.CodeOnlyDeactivate,
 				"only deactivation supported")
 			return
 		default:
-			s.Log.Error("failed to mass deactivate users", ERROR, err)
+			s.log(r).Error("failed to mass deactivate users", ERROR, err)
 			util.SendError(w,
 				http.StatusInternalServerError,
 				entity.CodeInternalError,
@@ -279,16 +438,239 @@ func (s *Services) UsersMassDeactivateHandler(
 		}
 	}
 
-	userIDs := make([]string, 0, len(req.Users))
-	for _, u := range req.Users {
-		userIDs = append(userIDs, u.UserID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode mass deactivate response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "failed to encode response")
+	}
+}
+
+// UsersBulkSetIsActiveHandler activates and deactivates users of any teams in
+// one call, reporting the outcome per user instead of failing the batch.
+func (s *Services) UsersBulkSetIsActiveHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var req UserBulkSetIsActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode bulk set active request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"invalid json")
+		return
+	}
+
+	if len(req.Users) == Zero {
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeEmptyRequest,
+			"empty request")
+		return
+	}
+
+	if err := validateUserBulkSetIsActiveRequest(&req); err != nil {
+		s.log(r).Warn("invalid bulk set active request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error())
+		return
+	}
+
+	report := s.UserService.BulkSetIsActive(r.Context(), req.Users)
+	if report.Failed > Zero {
+		s.log(r).Warn("bulk set active finished with failures",
+			"failed", report.Failed, "updated", report.Updated)
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode bulk set active response", ERROR, err)
+	}
+}
+
+func (s *Services) UserSetMaxOpenReviewsHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var req UserSetMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode set max open reviews request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"invalid json")
+		return
+	}
+
+	if err := validateUserID(req.UserID); err != nil {
+		s.log(r).Warn("invalid set max open reviews request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error())
+		return
+	}
+
+	if req.MaxOpenReviews != nil && *req.MaxOpenReviews < Zero {
+		s.log(r).Warn("negative max open reviews", userIDField, req.UserID)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"max_open_reviews must not be negative")
+		return
+	}
+
+	ctx := r.Context()
+
+	user, err := s.UserService.SetMaxOpenReviews(ctx, req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for max open reviews change", userIDField, req.UserID)
+			util.SendError(w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"user not found")
+			return
+		}
+
+		s.log(r).Error("failed to set max open reviews", errFieldName, err, userIDField, req.UserID)
+		util.SendError(w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error")
+		return
 	}
 
-	resp := UserMassChangeResponse{Deactivated: userIDs}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		s.Log.Error("failed to encode mass deactivate response", ERROR, err)
+	if err := json.NewEncoder(w).Encode(UserSetIsActiveResponse{User: *user}); err != nil {
+		s.log(r).Error("failed to encode set max open reviews response", ERROR, err)
 		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "failed to encode response")
 	}
 }
+
+func (s *Services) UserSetWorkingHoursHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var req UserSetWorkingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode set working hours request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"invalid json")
+		return
+	}
+
+	if err := validateUserSetWorkingHoursRequest(&req); err != nil {
+		s.log(r).Warn("invalid set working hours request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error())
+		return
+	}
+
+	ctx := r.Context()
+
+	user, err := s.UserService.SetWorkingHours(ctx,
+		req.UserID, req.TimeZone, req.WorkStart, req.WorkEnd)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			s.log(r).Warn("user not found for working hours change", userIDField, req.UserID)
+			util.SendError(w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"user not found")
+			return
+		}
+
+		s.log(r).Error("failed to set working hours", errFieldName, err, userIDField, req.UserID)
+		util.SendError(w,
+			http.StatusInternalServerError,
+			entity.CodeInternalError,
+			"internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(UserSetIsActiveResponse{User: *user}); err != nil {
+		s.log(r).Error("failed to encode set working hours response", ERROR, err)
+		util.SendError(w, http.StatusInternalServerError, entity.CodeInternalError, "failed to encode response")
+	}
+}
+
+// UsersHandoverHandler moves all open reviews of one user to a designated
+// delegate or list of delegates.
+func (s *Services) UsersHandoverHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var req UserHandoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log(r).Warn("failed to decode handover request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			"invalid json")
+		return
+	}
+
+	if err := validateUserHandoverRequest(&req); err != nil {
+		s.log(r).Warn("invalid handover request", ERROR, err)
+		util.SendError(w,
+			http.StatusBadRequest,
+			entity.CodeBadRequest,
+			err.Error())
+		return
+	}
+
+	dryRun, err := dryRunParam(r, false)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, entity.CodeBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.UserService.Handover(r.Context(), req.FromUserID, req.Delegates, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotFound):
+			s.log(r).Warn("user not found for handover", "from_user_id", req.FromUserID)
+			util.SendError(w,
+				http.StatusNotFound,
+				entity.CodeNotFound,
+				"user not found")
+		case errors.Is(err, entity.ErrInvalidDelegate):
+			s.log(r).Info("invalid delegate for handover", ERROR, err)
+			util.SendError(w,
+				http.StatusConflict,
+				entity.CodeInvalidDelegate,
+				err.Error())
+		default:
+			s.log(r).Error("failed to hand over reviews", ERROR, err)
+			util.SendError(w,
+				http.StatusInternalServerError,
+				entity.CodeInternalError,
+				"internal server error")
+		}
+
+		return
+	}
+
+	if !dryRun {
+		s.log(r).Info("reviews handed over", "from_user_id", req.FromUserID,
+			"pull_requests", len(report.PullRequests))
+	}
+
+	w.Header().Set(contentTypeHeader, applicationJSON)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.log(r).Error("failed to encode handover response", ERROR, err)
+	}
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+// notUnavailableFilter drops users inside a scheduled unavailability window;
+// it expects the users table to be visible as "users".
+const notUnavailableFilter = `
+      		AND NOT EXISTS (
+      			SELECT 1 FROM user_unavailability ua
+      			WHERE ua.user_id = users.user_id
+      			  AND ua.starts_at <= now() AND ua.ends_at > now()
+      		)`
+
+type AvailabilityRepository interface {
+	AddUnavailability(ctx context.Context, window *entity.Unavailability) error
+	ListUnavailability(ctx context.Context, userID string) ([]*entity.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	//nolint:revive // interface func
+	GetStartedHandovers(ctx context.Context) ([]*entity.Unavailability, error)
+	MarkHandedOver(ctx context.Context, id int64) error
+}
+
+type availabilityPGRepository struct {
+	db *database.DatabaseSource
+}
+
+//nolint:revive // idiomatic constructor sight
+func NewAvailabilityPGRepository(db *database.DatabaseSource) AvailabilityRepository {
+	return &availabilityPGRepository{db: db}
+}
+
+func (r *availabilityPGRepository) AddUnavailability(
+	ctx context.Context,
+	window *entity.Unavailability,
+) error {
+	return r.db.Querier(ctx).QueryRow(ctx,
+		`INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, handover)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id`,
+		window.UserID,
+		window.StartsAt,
+		window.EndsAt,
+		window.Reason,
+		window.Handover,
+	).Scan(&window.ID)
+}
+
+func (r *availabilityPGRepository) ListUnavailability(
+	ctx context.Context,
+	userID string,
+) ([]*entity.Unavailability, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT id, user_id, starts_at, ends_at, reason, handover, handed_over
+		 FROM user_unavailability
+		 WHERE user_id = $1 AND ends_at > now()
+		 ORDER BY starts_at`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUnavailability(rows)
+}
+
+func (r *availabilityPGRepository) DeleteUnavailability(
+	ctx context.Context,
+	id int64,
+) error {
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`DELETE FROM user_unavailability WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeNotFound))
+	}
+
+	return nil
+}
+
+//nolint:revive // sql query
+func (r *availabilityPGRepository) GetStartedHandovers(ctx context.Context) ([]*entity.Unavailability, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT id, user_id, starts_at, ends_at, reason, handover, handed_over
+		 FROM user_unavailability
+		 WHERE handover = TRUE
+		   AND handed_over = FALSE
+		   AND starts_at <= now() AND ends_at > now()
+		 ORDER BY starts_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUnavailability(rows)
+}
+
+func (r *availabilityPGRepository) MarkHandedOver(ctx context.Context, id int64) error {
+	_, err := r.db.Querier(ctx).Exec(ctx,
+		`UPDATE user_unavailability SET handed_over = TRUE WHERE id = $1`, id)
+
+	return err
+}
+
+type rowScanner interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+func scanUnavailability(rows rowScanner) ([]*entity.Unavailability, error) {
+	windows := []*entity.Unavailability{}
+
+	for rows.Next() {
+		var window entity.Unavailability
+		if err := rows.Scan(
+			&window.ID,
+			&window.UserID,
+			&window.StartsAt,
+			&window.EndsAt,
+			&window.Reason,
+			&window.Handover,
+			&window.HandedOver,
+		); err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &window)
+	}
+
+	return windows, rows.Err()
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+type ConflictRepository interface {
+	AddConflict(ctx context.Context, conflict *entity.ReviewerConflict) error
+	DeleteConflict(ctx context.Context, userID, otherUserID string) error
+	ListConflicts(ctx context.Context, userID string) ([]*entity.ReviewerConflict, error)
+	GetConflictingUsers(ctx context.Context, userID string) ([]string, error)
+}
+
+type conflictPGRepository struct {
+	db *database.DatabaseSource
+}
+
+//nolint:revive // idiomatic constructor sight
+func NewConflictPGRepository(db *database.DatabaseSource) ConflictRepository {
+	return &conflictPGRepository{db: db}
+}
+
+// orderedPair stores a pair once whichever way round it is given.
+func orderedPair(userID, otherUserID string) (string, string) {
+	if otherUserID < userID {
+		return otherUserID, userID
+	}
+
+	return userID, otherUserID
+}
+
+// AddConflict stores the pair, replacing the reason of an existing one.
+func (r *conflictPGRepository) AddConflict(
+	ctx context.Context,
+	conflict *entity.ReviewerConflict,
+) error {
+	userA, userB := orderedPair(conflict.UserID, conflict.OtherUserID)
+
+	return r.db.Querier(ctx).QueryRow(ctx,
+		`INSERT INTO reviewer_conflicts (user_a, user_b, reason)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (user_a, user_b) DO UPDATE SET reason = EXCLUDED.reason
+		 RETURNING created_at`,
+		userA,
+		userB,
+		conflict.Reason,
+	).Scan(&conflict.CreatedAt)
+}
+
+func (r *conflictPGRepository) DeleteConflict(
+	ctx context.Context,
+	userID, otherUserID string,
+) error {
+	userA, userB := orderedPair(userID, otherUserID)
+
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`DELETE FROM reviewer_conflicts WHERE user_a = $1 AND user_b = $2`,
+		userA, userB)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeNotFound))
+	}
+
+	return nil
+}
+
+// ListConflicts returns the pairs involving userID, or every pair when it is
+// empty.
+func (r *conflictPGRepository) ListConflicts(
+	ctx context.Context,
+	userID string,
+) ([]*entity.ReviewerConflict, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT user_a, user_b, reason, created_at
+		 FROM reviewer_conflicts
+		 WHERE $1 = '' OR user_a = $1 OR user_b = $1
+		 ORDER BY user_a, user_b`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []*entity.ReviewerConflict{}
+
+	for rows.Next() {
+		var conflict entity.ReviewerConflict
+		if err := rows.Scan(
+			&conflict.UserID,
+			&conflict.OtherUserID,
+			&conflict.Reason,
+			&conflict.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if conflict.OtherUserID == userID {
+			conflict.UserID, conflict.OtherUserID = conflict.OtherUserID, conflict.UserID
+		}
+
+		conflicts = append(conflicts, &conflict)
+	}
+
+	return conflicts, rows.Err()
+}
+
+// GetConflictingUsers returns the sorted users paired with userID.
+func (r *conflictPGRepository) GetConflictingUsers(
+	ctx context.Context,
+	userID string,
+) ([]string, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT user_b FROM reviewer_conflicts WHERE user_a = $1
+		 UNION
+		 SELECT user_a FROM reviewer_conflicts WHERE user_b = $1
+		 ORDER BY 1`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+type DecisionRepository interface {
+	AddDecision(ctx context.Context, decision *entity.AssignmentDecision) error
+	ListDecisions(ctx context.Context, prID string) ([]*entity.AssignmentDecision, error)
+}
+
+type decisionPGRepository struct {
+	db *database.DatabaseSource
+}
+
+//nolint:revive // idiomatic constructor sight
+func NewDecisionPGRepository(db *database.DatabaseSource) DecisionRepository {
+	return &decisionPGRepository{db: db}
+}
+
+func (r *decisionPGRepository) AddDecision(
+	ctx context.Context,
+	decision *entity.AssignmentDecision,
+) error {
+	pairings := decision.Pairings
+	if pairings == nil {
+		pairings = map[string]int{}
+	}
+
+	return r.db.Querier(ctx).QueryRow(ctx,
+		`INSERT INTO assignment_decisions
+		     (pull_request_id, operation, seed, candidates, ranking, picked, pairings, decided_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id`,
+		decision.PullRequestID,
+		decision.Operation,
+		decision.Seed,
+		decision.Candidates,
+		decision.Ranking,
+		decision.Picked,
+		pairings,
+		decision.DecidedAt,
+	).Scan(&decision.ID)
+}
+
+func (r *decisionPGRepository) ListDecisions(
+	ctx context.Context,
+	prID string,
+) ([]*entity.AssignmentDecision, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT id, pull_request_id, operation, seed, candidates, ranking, picked, pairings, decided_at
+		 FROM assignment_decisions
+		 WHERE pull_request_id = $1
+		 ORDER BY id`,
+		prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := []*entity.AssignmentDecision{}
+
+	for rows.Next() {
+		var decision entity.AssignmentDecision
+		if err := rows.Scan(
+			&decision.ID,
+			&decision.PullRequestID,
+			&decision.Operation,
+			&decision.Seed,
+			&decision.Candidates,
+			&decision.Ranking,
+			&decision.Picked,
+			&decision.Pairings,
+			&decision.DecidedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		decisions = append(decisions, &decision)
+	}
+
+	return decisions, rows.Err()
+}
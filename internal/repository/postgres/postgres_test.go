@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/migrations"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/migrate"
+)
+
+// testDatabaseEnv names a Postgres URL for repository tests; they are skipped
+// without it.
+const testDatabaseEnv = "REPOSITORY_TEST_DATABASE_URL"
+
+// testDatabase migrates a fresh schema, dropped when the test ends, and
+// returns a source whose connections use it.
+func testDatabase(t *testing.T) *database.DatabaseSource {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	ctx := t.Context()
+	schema := fmt.Sprintf("repository_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(admin.Close)
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	m, err := migrate.New(pool, migrations.FS)
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	return &database.DatabaseSource{Pool: pool}
+}
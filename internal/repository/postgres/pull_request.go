@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
@@ -21,6 +22,12 @@ type PullRequestRepository interface {
 	) error
 	//nolint:revive // interface func
 	GetOpenPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error)
+	GetRecentPairings(ctx context.Context, authorID string, since time.Time) (map[string]int, error)
+	ListPRsByRepository(
+		ctx context.Context,
+		repositoryName string,
+		status entity.PRStatus,
+	) ([]*entity.PullRequestShort, error)
 }
 
 type prPGRepository struct {
@@ -37,7 +44,7 @@ func (r *prPGRepository) CreatePR(
 	pr *entity.PullRequest,
 	reviewerIDs []string,
 ) error {
-	tx, err := r.db.Pool.Begin(ctx)
+	tx, err := r.db.Querier(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -63,12 +70,15 @@ func (r *prPGRepository) CreatePR(
 		ctx,
 		`INSERT INTO pull_requests (pull_request_id, 
                            pull_request_name, 
-                           author_id, status)
-		 VALUES ($1, $2, $3, $4)`,
+                           author_id, status,
+                           repository_name, number)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, 0))`,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		pr.Status,
+		pr.RepositoryName,
+		pr.Number,
 	)
 	if err != nil {
 		return err
@@ -93,9 +103,10 @@ func (r *prPGRepository) GetPR(
 ) (*entity.PullRequest, error) {
 	var pr entity.PullRequest
 
-	err := r.db.Pool.QueryRow(ctx,
+	err := r.db.Querier(ctx).QueryRow(ctx,
 		`SELECT pull_request_id, pull_request_name, author_id, status,
-		 created_at, merged_at
+		 created_at, merged_at,
+		 COALESCE(repository_name, ''), COALESCE(number, 0)
 		 FROM pull_requests
 		 WHERE pull_request_id = $1`,
 		prID,
@@ -106,12 +117,14 @@ func (r *prPGRepository) GetPR(
 		&pr.Status,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.RepositoryName,
+		&pr.Number,
 	)
 	if err != nil {
 		return nil, errors.New(string(entity.CodeNotFound))
 	}
 
-	rows, err := r.db.Pool.Query(ctx,
+	rows, err := r.db.Querier(ctx).Query(ctx,
 		`SELECT reviewer_id
 		 FROM pr_reviewers
 		 WHERE pull_request_id = $1
@@ -139,7 +152,7 @@ func (r *prPGRepository) GetPR(
 //nolint:revive // func
 func (r *prPGRepository) PRExists(ctx context.Context, prID string) (bool, error) {
 	var exists bool
-	err := r.db.Pool.QueryRow(ctx,
+	err := r.db.Querier(ctx).QueryRow(ctx,
 		//nolint:revive // monolit sql query
 		`SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`, prID).Scan(&exists)
 
@@ -148,7 +161,7 @@ func (r *prPGRepository) PRExists(ctx context.Context, prID string) (bool, error
 
 //nolint:revive // func
 func (r *prPGRepository) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
-	_, err := r.db.Pool.Exec(ctx,
+	_, err := r.db.Querier(ctx).Exec(ctx,
 		`UPDATE pull_requests
 		 SET status = $1,
 		     merged_at = CASE WHEN $1 = 'MERGED' AND merged_at 
@@ -161,16 +174,22 @@ func (r *prPGRepository) UpdatePR(ctx context.Context, pr *entity.PullRequest) e
 
 //nolint:revive // sql query
 func (r *prPGRepository) UpdateReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
-	tx, err := r.db.Pool.Begin(ctx)
+	tx, err := r.db.Querier(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
 	//nolint:errcheck // Rollback in defer is best-effort cleanup
 	defer tx.Rollback(ctx)
 
+	if reviewerIDs == nil {
+		reviewerIDs = []string{}
+	}
+
+	// reviewers that stay keep their assigned_at so SLA tracking is not reset
 	_, err = tx.Exec(ctx,
-		`DELETE FROM pr_reviewers WHERE pull_request_id = $1`,
-		prID)
+		`DELETE FROM pr_reviewers
+		 WHERE pull_request_id = $1 AND reviewer_id <> ALL($2)`,
+		prID, reviewerIDs)
 
 	if err != nil {
 		return err
@@ -179,7 +198,8 @@ func (r *prPGRepository) UpdateReviewers(ctx context.Context, prID string, revie
 	for _, reviewerID := range reviewerIDs {
 		_, err = tx.Exec(ctx,
 			`INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-			 VALUES ($1, $2)`,
+			 VALUES ($1, $2)
+			 ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING`,
 			prID, reviewerID)
 		if err != nil {
 			return err
@@ -191,7 +211,7 @@ func (r *prPGRepository) UpdateReviewers(ctx context.Context, prID string, revie
 
 //nolint:revive // func
 func (r *prPGRepository) GetOpenPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
-	rows, err := r.db.Pool.Query(ctx,
+	rows, err := r.db.Querier(ctx).Query(ctx,
 		`SELECT pr.pull_request_id
 		 FROM pr_reviewers prr
 		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
@@ -215,3 +235,80 @@ func (r *prPGRepository) GetOpenPRsByReviewer(ctx context.Context, reviewerID st
 
 	return prIDs, nil
 }
+
+// GetRecentPairings counts, per reviewer, the PRs of authorID they were
+// assigned to since the given moment. Reviewers replaced on a PR no longer
+// count for it.
+func (r *prPGRepository) GetRecentPairings(
+	ctx context.Context,
+	authorID string,
+	since time.Time,
+) (map[string]int, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT prr.reviewer_id, COUNT(*)
+		 FROM pr_reviewers prr
+		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		 WHERE pr.author_id = $1 AND prr.assigned_at >= $2
+		 GROUP BY prr.reviewer_id`,
+		authorID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pairings := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			reviewerID string
+			count      int
+		)
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, err
+		}
+
+		pairings[reviewerID] = count
+	}
+
+	return pairings, rows.Err()
+}
+
+//nolint:revive // sql query
+func (r *prPGRepository) ListPRsByRepository(
+	ctx context.Context,
+	repositoryName string,
+	status entity.PRStatus,
+) ([]*entity.PullRequestShort, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT pull_request_id, pull_request_name, author_id, status,
+		 repository_name, number
+		 FROM pull_requests
+		 WHERE repository_name = $1
+		   AND ($2 = '' OR status = $2)
+		 ORDER BY number`,
+		repositoryName, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := []*entity.PullRequestShort{}
+
+	for rows.Next() {
+		var pr entity.PullRequestShort
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.RepositoryName,
+			&pr.Number,
+		); err != nil {
+			return nil, err
+		}
+
+		prs = append(prs, &pr)
+	}
+
+	return prs, rows.Err()
+}
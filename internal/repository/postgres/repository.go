@@ -8,7 +8,14 @@ type Repository struct {
 	Teams        TeamRepository
 	Users        UserRepository
 	PullRequests PullRequestRepository
+	Repositories RepositoryRepository
+	Availability AvailabilityRepository
+	SLA          SLARepository
 	Stats        StatsRepository
+	Snapshots    SnapshotRepository
+	Conflicts    ConflictRepository
+	Decisions    DecisionRepository
+	Tx           Transactor
 }
 
 func CreateNewDBRepository(db *database.DatabaseSource) *Repository {
@@ -16,6 +23,13 @@ func CreateNewDBRepository(db *database.DatabaseSource) *Repository {
 		Teams:        NewTeamPGRepository(db),
 		Users:        NewUserPGRepository(db),
 		PullRequests: NewPullRequestPGRepository(db),
+		Repositories: NewRepositoryPGRepository(db),
+		Availability: NewAvailabilityPGRepository(db),
+		SLA:          NewSLAPGRepository(db),
 		Stats:        NewStatsPGRepository(db),
+		Snapshots:    NewSnapshotPGRepository(db),
+		Conflicts:    NewConflictPGRepository(db),
+		Decisions:    NewDecisionPGRepository(db),
+		Tx:           db,
 	}
 }
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+type RepositoryRepository interface {
+	AddRepository(ctx context.Context, repository *entity.Repository) error
+	GetRepository(ctx context.Context, name string) (*entity.Repository, error)
+	RepositoryExists(ctx context.Context, name string) (bool, error)
+}
+
+type repositoryPGRepository struct {
+	db *database.DatabaseSource
+}
+
+//nolint:revive // idiomatic constructor sight
+func NewRepositoryPGRepository(db *database.DatabaseSource) RepositoryRepository {
+	return &repositoryPGRepository{db: db}
+}
+
+func (r *repositoryPGRepository) AddRepository(
+	ctx context.Context,
+	repository *entity.Repository,
+) error {
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`INSERT INTO repositories (repository_name, vcs, default_team)
+		 VALUES ($1, $2, NULLIF($3, ''))
+		 ON CONFLICT (repository_name) DO NOTHING`,
+		repository.RepositoryName,
+		repository.VCS,
+		repository.DefaultTeam,
+	)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeRepositoryExists))
+	}
+
+	return nil
+}
+
+func (r *repositoryPGRepository) GetRepository(
+	ctx context.Context,
+	name string,
+) (*entity.Repository, error) {
+	var repository entity.Repository
+
+	err := r.db.Querier(ctx).QueryRow(ctx,
+		`SELECT repository_name, vcs, COALESCE(default_team, '')
+		 FROM repositories
+		 WHERE repository_name = $1`,
+		name,
+	).Scan(&repository.RepositoryName, &repository.VCS, &repository.DefaultTeam)
+	if err != nil {
+		return nil, errors.New(string(entity.CodeNotFound))
+	}
+
+	return &repository, nil
+}
+
+func (r *repositoryPGRepository) RepositoryExists(
+	ctx context.Context,
+	name string,
+) (bool, error) {
+	var exists bool
+	err := r.db.Querier(ctx).QueryRow(
+		ctx,
+		`SELECT EXISTS(SELECT 1 FROM repositories WHERE repository_name = $1)`,
+		name,
+	).Scan(&exists)
+
+	return exists, err
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+// overdueReviewsFrom selects open reviews assigned longer than the SLA of the
+// reviewer's team; teams without an SLA are never overdue.
+const overdueReviewsFrom = `
+    FROM pr_reviewers prr
+    JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+    JOIN users u ON u.user_id = prr.reviewer_id
+    JOIN teams t ON t.team_name = u.team_name
+    WHERE pr.status = 'OPEN'
+      AND t.review_sla_minutes IS NOT NULL
+      AND prr.assigned_at + make_interval(mins => t.review_sla_minutes) < now()`
+
+type SLARepository interface {
+	GetOverdueReviews(ctx context.Context, onlyUnflagged bool) ([]*entity.OverdueReview, error)
+	MarkOverdueFlagged(ctx context.Context, prID, reviewerID string) error
+}
+
+type slaPGRepository struct {
+	db *database.DatabaseSource
+}
+
+func NewSLAPGRepository(db *database.DatabaseSource) SLARepository {
+	return &slaPGRepository{db: db}
+}
+
+func (r *slaPGRepository) GetOverdueReviews(
+	ctx context.Context,
+	onlyUnflagged bool,
+) ([]*entity.OverdueReview, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT prr.pull_request_id, pr.pull_request_name, pr.author_id,
+		        prr.reviewer_id, u.team_name, COALESCE(t.lead_user_id, ''),
+		        t.review_sla_minutes, prr.assigned_at, prr.overdue_flagged_at`+
+			overdueReviewsFrom+`
+		   AND (NOT $1 OR prr.overdue_flagged_at IS NULL)
+		 ORDER BY prr.assigned_at`,
+		onlyUnflagged,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*entity.OverdueReview{}
+
+	for rows.Next() {
+		var review entity.OverdueReview
+		if err := rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.ReviewerID,
+			&review.TeamName,
+			&review.LeadUserID,
+			&review.SLAMinutes,
+			&review.AssignedAt,
+			&review.FlaggedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *slaPGRepository) MarkOverdueFlagged(ctx context.Context, prID, reviewerID string) error {
+	_, err := r.db.Querier(ctx).Exec(ctx,
+		`UPDATE pr_reviewers
+		 SET overdue_flagged_at = now()
+		 WHERE pull_request_id = $1 AND reviewer_id = $2`,
+		prID, reviewerID)
+
+	return err
+}
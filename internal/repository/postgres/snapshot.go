@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
+)
+
+// snapshotQueries compute each metric as (subject, value) rows for the UTC
+// day passed as $1.
+var snapshotQueries = map[entity.StatsMetric]string{
+	entity.MetricOpenPRs: `SELECT '', COUNT(*)::float8
+		 FROM pull_requests WHERE status = 'OPEN'`,
+	entity.MetricReviewersPerPR: `SELECT '', COALESCE(AVG(cnt), 0)::float8
+		 FROM (SELECT COUNT(prr.reviewer_id) AS cnt
+		       FROM pull_requests pr
+		       LEFT JOIN pr_reviewers prr ON prr.pull_request_id = pr.pull_request_id
+		       WHERE pr.status = 'OPEN'
+		       GROUP BY pr.pull_request_id) per_pr`,
+	entity.MetricOpenReviewsByUser: `SELECT prr.reviewer_id, COUNT(*)::float8
+		 FROM pr_reviewers prr
+		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		 WHERE pr.status = 'OPEN'
+		 GROUP BY prr.reviewer_id`,
+	entity.MetricMerges: `SELECT '', COUNT(*)::float8
+		 FROM pull_requests
+		 WHERE merged_at >= $1::date::timestamp AT TIME ZONE 'UTC'
+		   AND merged_at < ($1::date + 1)::timestamp AT TIME ZONE 'UTC'`,
+}
+
+type SnapshotRepository interface {
+	TakeSnapshot(ctx context.Context, day time.Time) error
+	GetHistory(
+		ctx context.Context,
+		metric entity.StatsMetric,
+		subject string,
+		from, to time.Time,
+	) ([]*entity.StatsPoint, error)
+}
+
+type snapshotPGRepository struct {
+	db *database.DatabaseSource
+}
+
+func NewSnapshotPGRepository(db *database.DatabaseSource) SnapshotRepository {
+	return &snapshotPGRepository{db: db}
+}
+
+// TakeSnapshot replaces the stored aggregates of the day, so running it
+// several times a day keeps the latest values.
+func (r *snapshotPGRepository) TakeSnapshot(ctx context.Context, day time.Time) error {
+	date := snapshotDate(day)
+
+	tx, err := r.db.Querier(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck // Rollback in defer is best-effort cleanup
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`DELETE FROM stats_snapshots WHERE snapshot_date = $1::date`, date)
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range entity.StatsMetrics {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO stats_snapshots (snapshot_date, metric, subject, value)
+			 SELECT $1::date, '`+string(metric)+`', subject, value
+			 FROM (`+snapshotQueries[metric]+`) AS m(subject, value)`,
+			date)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *snapshotPGRepository) GetHistory(
+	ctx context.Context,
+	metric entity.StatsMetric,
+	subject string,
+	from, to time.Time,
+) ([]*entity.StatsPoint, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT snapshot_date, subject, value
+		 FROM stats_snapshots
+		 WHERE metric = $1
+		   AND ($2 = '' OR subject = $2)
+		   AND snapshot_date >= $3::date AND snapshot_date <= $4::date
+		 ORDER BY subject, snapshot_date`,
+		metric, subject, snapshotDate(from), snapshotDate(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []*entity.StatsPoint{}
+
+	for rows.Next() {
+		var point entity.StatsPoint
+		if err := rows.Scan(&point.Date, &point.Subject, &point.Value); err != nil {
+			return nil, err
+		}
+
+		points = append(points, &point)
+	}
+
+	return points, rows.Err()
+}
+
+// snapshotDate is the UTC calendar day of t. It is sent as text because
+// casting a timestamp to date would use the session time zone.
+func snapshotDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
@@ -2,8 +2,10 @@ package postgres
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
 )
 
@@ -13,7 +15,37 @@ const (
 
 type StatsRepository interface {
 	GetAssignedReviewersCountPerPR(ctx context.Context) (map[string]int, error)
+	GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error)
 	GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error)
+	GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error)
+	GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error)
+	GetTurnaround(
+		ctx context.Context,
+		from, to time.Time,
+		groupBy entity.TurnaroundGroupBy,
+	) ([]*entity.TurnaroundGroup, error)
+	GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error)
+	GetFairnessData(
+		ctx context.Context,
+		teamName string,
+		from, to time.Time,
+	) ([]*entity.FairnessMember, error)
+}
+
+// turnaroundGroupColumns maps a grouping to the users column used as key;
+// only these values are ever concatenated into SQL.
+var turnaroundGroupColumns = map[entity.TurnaroundGroupBy]string{
+	entity.GroupByTeam: "u.team_name",
+	entity.GroupByUser: "u.user_id",
+}
+
+// percentiles expands to count, median and p90 of a duration in seconds.
+func percentiles(duration string) string {
+	seconds := "EXTRACT(EPOCH FROM " + duration + ")"
+
+	return `COUNT(` + seconds + `),
+		 percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + seconds + `),
+		 percentile_cont(0.9) WITHIN GROUP (ORDER BY ` + seconds + `)`
 }
 
 type statsPGRepository struct {
@@ -29,7 +61,7 @@ func (r *statsPGRepository) GetAssignedReviewersCountPerPR(ctx context.Context)
 	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
 	defer cancel()
 
-	rows, err := r.db.Pool.Query(qctx,
+	rows, err := r.db.Querier(ctx).Query(qctx,
 		`SELECT pr.pull_request_id, COUNT(prr.reviewer_id) AS cnt
 		 FROM pull_requests pr
 		 LEFT JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
@@ -53,12 +85,45 @@ func (r *statsPGRepository) GetAssignedReviewersCountPerPR(ctx context.Context)
 	return res, nil
 }
 
+// GetOpenPRCountByReviewerCount counts open PRs by how many reviewers they
+// have, without reading every PR.
+func (r *statsPGRepository) GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`SELECT reviewer_count, COUNT(*)
+		 FROM (
+			SELECT COUNT(prr.reviewer_id) AS reviewer_count
+			FROM pull_requests pr
+			LEFT JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+			WHERE pr.status = 'OPEN'
+			GROUP BY pr.pull_request_id
+		 ) counts
+		 GROUP BY reviewer_count`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int]int)
+	for rows.Next() {
+		var reviewers, cnt int
+		if err := rows.Scan(&reviewers, &cnt); err != nil {
+			return nil, err
+		}
+		res[reviewers] = cnt
+	}
+
+	return res, rows.Err()
+}
+
 //nolint:revive // monolith func
 func (r *statsPGRepository) GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error) {
 	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
 	defer cancel()
 
-	rows, err := r.db.Pool.Query(qctx,
+	rows, err := r.db.Querier(ctx).Query(qctx,
 		`SELECT prr.reviewer_id, COUNT(DISTINCT pr.pull_request_id) AS cnt
 		 FROM pr_reviewers prr
 		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
@@ -81,3 +146,262 @@ func (r *statsPGRepository) GetOpenPRCountPerUser(ctx context.Context) (map[stri
 
 	return res, nil
 }
+
+//nolint:revive // monolith func
+func (r *statsPGRepository) GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`SELECT repository_name, COUNT(*) AS cnt
+		 FROM pull_requests
+		 WHERE status = 'OPEN' AND repository_name IS NOT NULL
+		 GROUP BY repository_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int)
+	for rows.Next() {
+		var repositoryName string
+		var cnt int
+		if err := rows.Scan(&repositoryName, &cnt); err != nil {
+			return nil, err
+		}
+		res[repositoryName] = cnt
+	}
+
+	return res, nil
+}
+
+//nolint:revive // monolith func
+func (r *statsPGRepository) GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`SELECT u.team_name, COUNT(*) AS cnt`+overdueReviewsFrom+`
+		 GROUP BY u.team_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int)
+	for rows.Next() {
+		var teamName string
+		var cnt int
+		if err := rows.Scan(&teamName, &cnt); err != nil {
+			return nil, err
+		}
+		res[teamName] = cnt
+	}
+
+	return res, nil
+}
+
+// GetTurnaround aggregates PRs created and reviews assigned within [from, to).
+//
+//nolint:revive,funlen // monolith func
+func (r *statsPGRepository) GetTurnaround(
+	ctx context.Context,
+	from, to time.Time,
+	groupBy entity.TurnaroundGroupBy,
+) ([]*entity.TurnaroundGroup, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	column, ok := turnaroundGroupColumns[groupBy]
+	if !ok {
+		column = turnaroundGroupColumns[entity.GroupByTeam]
+	}
+
+	groups := make(map[string]*entity.TurnaroundGroup)
+	group := func(key string) *entity.TurnaroundGroup {
+		if g, ok := groups[key]; ok {
+			return g
+		}
+		g := &entity.TurnaroundGroup{Key: key}
+		groups[key] = g
+		return g
+	}
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`WITH prs AS (
+		     SELECT `+column+` AS key, pr.created_at, pr.merged_at,
+		            (SELECT MIN(prr.assigned_at) FROM pr_reviewers prr
+		             WHERE prr.pull_request_id = pr.pull_request_id) AS first_assigned_at
+		     FROM pull_requests pr
+		     JOIN users u ON u.user_id = pr.author_id
+		     WHERE pr.created_at >= $1 AND pr.created_at < $2
+		 )
+		 SELECT key,
+		 `+percentiles("first_assigned_at - created_at")+`,
+		 `+percentiles("merged_at - created_at")+`
+		 FROM prs
+		 GROUP BY key`,
+		from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var key string
+		var assignment, merge entity.DurationStats
+		if err := rows.Scan(&key,
+			&assignment.Count, &assignment.MedianSeconds, &assignment.P90Seconds,
+			&merge.Count, &merge.MedianSeconds, &merge.P90Seconds,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		g := group(key)
+		g.TimeToFirstAssignment = assignment
+		g.TimeToMerge = merge
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// reviews still open are measured up to now
+	rows, err = r.db.Querier(ctx).Query(qctx,
+		`SELECT `+column+` AS key,
+		 `+percentiles("COALESCE(pr.merged_at, now()) - prr.assigned_at")+`
+		 FROM pr_reviewers prr
+		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		 JOIN users u ON u.user_id = prr.reviewer_id
+		 WHERE prr.assigned_at >= $1 AND prr.assigned_at < $2
+		 GROUP BY key`,
+		from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var open entity.DurationStats
+		if err := rows.Scan(&key, &open.Count, &open.MedianSeconds, &open.P90Seconds); err != nil {
+			return nil, err
+		}
+
+		group(key).ReviewOpenDuration = open
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]*entity.TurnaroundGroup, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, g)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+
+	return res, nil
+}
+
+// GetFairnessData returns every team member with the reviews assigned to them
+// in [from, to) and the days of that period not covered by unavailability.
+// Overlapping windows are merged first, so no day is subtracted twice.
+//
+//nolint:revive // monolith func
+func (r *statsPGRepository) GetFairnessData(
+	ctx context.Context,
+	teamName string,
+	from, to time.Time,
+) ([]*entity.FairnessMember, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`SELECT u.user_id, u.is_active,
+		        (SELECT COUNT(*) FROM pr_reviewers prr
+		         WHERE prr.reviewer_id = u.user_id
+		           AND prr.assigned_at >= $2 AND prr.assigned_at < $3),
+		        EXTRACT(EPOCH FROM $3::timestamptz - $2::timestamptz) / 86400
+		        - COALESCE((SELECT SUM(EXTRACT(EPOCH FROM upper(away) - lower(away)))
+		                    FROM unnest((
+		                        SELECT range_agg(tstzrange(GREATEST(ua.starts_at, $2),
+		                                                   LEAST(ua.ends_at, $3)))
+		                        FROM user_unavailability ua
+		                        WHERE ua.user_id = u.user_id
+		                          AND ua.starts_at < $3 AND ua.ends_at > $2)) AS away), 0) / 86400
+		 FROM users u
+		 WHERE u.team_name = $1
+		 ORDER BY u.user_id`,
+		teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*entity.FairnessMember{}
+
+	for rows.Next() {
+		var member entity.FairnessMember
+		if err := rows.Scan(
+			&member.UserID,
+			&member.IsActive,
+			&member.Assignments,
+			&member.ActiveDays,
+		); err != nil {
+			return nil, err
+		}
+
+		members = append(members, &member)
+	}
+
+	return members, rows.Err()
+}
+
+//nolint:revive // monolith func
+func (r *statsPGRepository) GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error) {
+	qctx, cancel := context.WithTimeout(ctx, ContextTimeout*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Querier(ctx).Query(qctx,
+		`WITH reviews AS (
+		     SELECT u.team_name, prr.reviewer_id, COUNT(*) AS cnt
+		     FROM pr_reviewers prr
+		     JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		     JOIN users u ON u.user_id = prr.reviewer_id
+		     WHERE pr.status = 'OPEN'
+		     GROUP BY u.team_name, prr.reviewer_id
+		 )
+		 SELECT t.team_name,
+		        (SELECT COUNT(*) FROM pull_requests pr
+		         JOIN users a ON a.user_id = pr.author_id
+		         WHERE a.team_name = t.team_name AND pr.status = 'OPEN'),
+		        COALESCE((SELECT SUM(cnt) FROM reviews WHERE reviews.team_name = t.team_name), 0),
+		        COALESCE((SELECT MAX(cnt) FROM reviews WHERE reviews.team_name = t.team_name), 0),
+		        (SELECT COUNT(*) FROM users u
+		         WHERE u.team_name = t.team_name AND u.is_active)
+		 FROM teams t`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.TeamLoad
+	for rows.Next() {
+		var load entity.TeamLoad
+		if err := rows.Scan(
+			&load.TeamName,
+			&load.OpenPRs,
+			&load.OpenReviews,
+			&load.MaxMemberReviews,
+			&load.ActiveMembers,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, &load)
+	}
+
+	return res, rows.Err()
+}
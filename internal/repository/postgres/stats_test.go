@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestStatsRepository_FairnessMergesOverlappingWindows(t *testing.T) {
+	db := testDatabase(t)
+	ctx := t.Context()
+	availability := NewAvailabilityPGRepository(db)
+
+	require.NoError(t, NewTeamPGRepository(db).AddTeam(ctx, &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}))
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	// the windows share a day, so the user is away five days, not six
+	for _, window := range []*entity.Unavailability{
+		{UserID: "u1", StartsAt: from.AddDate(0, 0, 2), EndsAt: from.AddDate(0, 0, 5)},
+		{UserID: "u1", StartsAt: from.AddDate(0, 0, 4), EndsAt: from.AddDate(0, 0, 7)},
+	} {
+		require.NoError(t, availability.AddUnavailability(ctx, window))
+	}
+
+	members, err := NewStatsPGRepository(db).GetFairnessData(ctx, "backend", from, to)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.InDelta(t, 5.0, members[0].ActiveDays, 1e-9)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/jackc/pgx/v5"
+
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
 )
@@ -12,8 +14,28 @@ type TeamRepository interface {
 	AddTeam(ctx context.Context, team *entity.Team) error
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
+	SetReviewSLA(ctx context.Context, teamName string, slaMinutes *int, leadUserID string) error
+	ListTeamNames(ctx context.Context) ([]string, error)
+	ImportTeams(ctx context.Context, teams []entity.Team) error
 }
 
+// upsertMemberQuery creates a member or overwrites an existing user, moving
+// them into the given team. An existing user keeps their review limit and
+// working hours unless new ones are given.
+const upsertMemberQuery = `
+	INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews,
+	 time_zone, work_start, work_end)
+	VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'UTC'),
+	 NULLIF($7, '')::time, NULLIF($8, '')::time)
+	ON CONFLICT (user_id) DO UPDATE SET
+	 username = EXCLUDED.username,
+	 team_name = EXCLUDED.team_name,
+	 is_active = EXCLUDED.is_active,
+	 max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews),
+	 time_zone = CASE WHEN $6 = '' THEN users.time_zone ELSE EXCLUDED.time_zone END,
+	 work_start = CASE WHEN $7 = '' THEN users.work_start ELSE EXCLUDED.work_start END,
+	 work_end = CASE WHEN $8 = '' THEN users.work_end ELSE EXCLUDED.work_end END`
+
 type teamPGRepository struct {
 	db *database.DatabaseSource
 }
@@ -26,7 +48,7 @@ func (r *teamPGRepository) AddTeam(
 	ctx context.Context,
 	team *entity.Team,
 ) error {
-	tx, err := r.db.Pool.Begin(ctx)
+	tx, err := r.db.Querier(ctx).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -49,8 +71,11 @@ func (r *teamPGRepository) AddTeam(
 	}
 
 	_, err = tx.Exec(ctx,
-		`INSERT INTO teams (team_name) VALUES ($1)`,
+		`INSERT INTO teams (team_name, default_max_open_reviews, review_sla_minutes)
+		 VALUES ($1, $2, $3)`,
 		team.TeamName,
+		team.DefaultMaxOpenReviews,
+		team.ReviewSLAMinutes,
 	)
 
 	if err != nil {
@@ -59,13 +84,19 @@ func (r *teamPGRepository) AddTeam(
 
 	for _, member := range team.Members {
 		_, err = tx.Exec(ctx,
-			`INSERT INTO users (user_id, username, team_name, is_active)
-			 VALUES ($1, $2, $3, $4)
-			 ON CONFLICT (user_id) DO UPDATE SET
-			 username = EXCLUDED.username,
-			 team_name = EXCLUDED.team_name,
-			 is_active = EXCLUDED.is_active`,
-			member.UserID, member.Username, team.TeamName, member.IsActive)
+			upsertMemberQuery,
+			member.UserID, member.Username, team.TeamName, member.IsActive,
+			member.MaxOpenReviews, member.TimeZone, member.WorkStart, member.WorkEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the lead references users, so it can only be set once members exist
+	if team.LeadUserID != "" {
+		_, err = tx.Exec(ctx,
+			`UPDATE teams SET lead_user_id = $1 WHERE team_name = $2`,
+			team.LeadUserID, team.TeamName)
 		if err != nil {
 			return err
 		}
@@ -78,22 +109,24 @@ func (r *teamPGRepository) GetTeam(
 	ctx context.Context,
 	teamName string,
 ) (*entity.Team, error) {
-	var exists bool
+	var defaultMaxOpenReviews, reviewSLAMinutes *int
 
-	err := r.db.Pool.QueryRow(ctx,
+	var leadUserID string
 
-		`SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`, teamName).
-		Scan(&exists)
+	err := r.db.Querier(ctx).QueryRow(ctx,
+		`SELECT default_max_open_reviews, review_sla_minutes, COALESCE(lead_user_id, '')
+		 FROM teams WHERE team_name = $1`, teamName).
+		Scan(&defaultMaxOpenReviews, &reviewSLAMinutes, &leadUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New(string(entity.CodeNotFound))
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if !exists {
-		return nil, errors.New(string(entity.CodeNotFound))
-	}
-
-	rows, err := r.db.Pool.Query(ctx,
-		`SELECT user_id, username, is_active
+	rows, err := r.db.Querier(ctx).Query(ctx,
+		`SELECT user_id, username, is_active, max_open_reviews,
+		 `+workingHoursColumns+`
 		 FROM users
 		 WHERE team_name = $1
 		 ORDER BY user_id`,
@@ -112,6 +145,10 @@ func (r *teamPGRepository) GetTeam(
 			&member.UserID,
 			&member.Username,
 			&member.IsActive,
+			&member.MaxOpenReviews,
+			&member.TimeZone,
+			&member.WorkStart,
+			&member.WorkEnd,
 		); err != nil {
 			return nil, err
 		}
@@ -120,8 +157,11 @@ func (r *teamPGRepository) GetTeam(
 	}
 
 	return &entity.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:              teamName,
+		DefaultMaxOpenReviews: defaultMaxOpenReviews,
+		ReviewSLAMinutes:      reviewSLAMinutes,
+		LeadUserID:            leadUserID,
+		Members:               members,
 	}, nil
 }
 
@@ -130,7 +170,7 @@ func (r *teamPGRepository) TeamExists(
 	teamName string,
 ) (bool, error) {
 	var exists bool
-	err := r.db.Pool.QueryRow(
+	err := r.db.Querier(ctx).QueryRow(
 		ctx,
 		`SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`,
 		teamName,
@@ -138,3 +178,93 @@ func (r *teamPGRepository) TeamExists(
 
 	return exists, err
 }
+
+func (r *teamPGRepository) SetReviewSLA(
+	ctx context.Context,
+	teamName string,
+	slaMinutes *int,
+	leadUserID string,
+) error {
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`UPDATE teams
+		 SET review_sla_minutes = $1,
+		     lead_user_id = NULLIF($2, '')
+		 WHERE team_name = $3`,
+		slaMinutes, leadUserID, teamName,
+	)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeNotFound))
+	}
+
+	return nil
+}
+
+func (r *teamPGRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Querier(ctx).Query(ctx, `SELECT team_name FROM teams ORDER BY team_name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// ImportTeams upserts the teams and their members in one transaction. Team
+// settings missing from the import keep their current values; members absent
+// from the import are left untouched.
+func (r *teamPGRepository) ImportTeams(
+	ctx context.Context,
+	teams []entity.Team,
+) error {
+	tx, err := r.db.Querier(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck // Rollback in defer is best-effort cleanup
+	defer tx.Rollback(ctx)
+
+	for _, team := range teams {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO teams (team_name, default_max_open_reviews, review_sla_minutes)
+			 VALUES ($1, $2, $3)
+			 ON CONFLICT (team_name) DO UPDATE SET
+			 default_max_open_reviews = COALESCE(EXCLUDED.default_max_open_reviews,
+			                                     teams.default_max_open_reviews),
+			 review_sla_minutes = COALESCE(EXCLUDED.review_sla_minutes, teams.review_sla_minutes)`,
+			team.TeamName, team.DefaultMaxOpenReviews, team.ReviewSLAMinutes)
+		if err != nil {
+			return err
+		}
+	}
+
+	// members may move between imported teams, so all teams exist first
+	for _, team := range teams {
+		for _, member := range team.Members {
+			_, err = tx.Exec(ctx, upsertMemberQuery,
+				member.UserID, member.Username, team.TeamName, member.IsActive,
+				member.MaxOpenReviews, member.TimeZone, member.WorkStart, member.WorkEnd)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, team := range teams {
+		if team.LeadUserID == "" {
+			continue
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE teams SET lead_user_id = $1 WHERE team_name = $2`,
+			team.LeadUserID, team.TeamName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestTeamRepository_UpsertKeepsWorkingHours(t *testing.T) {
+	db := testDatabase(t)
+	ctx := t.Context()
+	teams := NewTeamPGRepository(db)
+	users := NewUserPGRepository(db)
+
+	require.NoError(t, teams.AddTeam(ctx, &entity.Team{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}))
+	require.NoError(t, users.SetWorkingHours(ctx, "u1", "Europe/Moscow", "09:00", "18:00"))
+
+	// the member moves to a new team without any hours in the request
+	require.NoError(t, teams.AddTeam(ctx, &entity.Team{
+		TeamName: "platform",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}))
+
+	user, err := users.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", user.TeamName)
+	assert.Equal(t, "Europe/Moscow", user.TimeZone)
+	assert.Equal(t, "09:00", user.WorkStart)
+	assert.Equal(t, "18:00", user.WorkEnd)
+
+	// an import without hours columns keeps them too, while given ones apply
+	require.NoError(t, teams.ImportTeams(ctx, []entity.Team{{
+		TeamName: "platform",
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true, TimeZone: "Asia/Tokyo",
+				WorkStart: "10:00", WorkEnd: "19:00"},
+		},
+	}}))
+
+	user, err = users.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Moscow", user.TimeZone)
+	assert.Equal(t, "09:00", user.WorkStart)
+	assert.Equal(t, "18:00", user.WorkEnd)
+
+	user, err = users.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", user.TimeZone)
+	assert.Equal(t, "10:00", user.WorkStart)
+}
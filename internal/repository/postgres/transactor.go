@@ -0,0 +1,11 @@
+package postgres
+
+import (
+	"context"
+)
+
+// Transactor runs fn in one database transaction; repository calls made with
+// the context passed to fn take part in it. Returning an error rolls it back.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -4,15 +4,32 @@ import (
 	"context"
 	"errors"
 
-	"github.com/jackc/pgx/v5"
-
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/database"
 )
 
-const (
-	initialReviewerCap = 0
-)
+// effectiveCapacity resolves a user's review limit, falling back to the team
+// default; NULL means unlimited.
+const effectiveCapacity = `COALESCE(users.max_open_reviews,
+      			(SELECT t.default_max_open_reviews FROM teams t WHERE t.team_name = users.team_name))`
+
+const openReviewCount = `(SELECT COUNT(*) FROM pr_reviewers prr
+      			JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+      			WHERE prr.reviewer_id = users.user_id AND pr.status = 'OPEN')`
+
+// hasCapacityFilter drops users who already hold as many open reviews as
+// their limit allows.
+const hasCapacityFilter = `
+      		AND (` + effectiveCapacity + ` IS NULL
+      			OR ` + openReviewCount + ` < ` + effectiveCapacity + `)`
+
+// workingHoursColumns reads time zone and working window as "HH:MM" strings.
+const workingHoursColumns = `time_zone,
+        	COALESCE(to_char(work_start, 'HH24:MI'), ''),
+        	COALESCE(to_char(work_end, 'HH24:MI'), '')`
+
+// eligibleReviewerFilter is appended to every candidate query on users.
+const eligibleReviewerFilter = notUnavailableFilter + hasCapacityFilter
 
 type UserRepository interface {
 	GetUser(ctx context.Context, userID string) (*entity.User, error)
@@ -24,8 +41,10 @@ type UserRepository interface {
 	) ([]*entity.User, error)
 	//nolint:revive // monolith func
 	GetPRsForReviewer(ctx context.Context, userID string) ([]*entity.PullRequestShort, error)
-	//nolint:revive // monolith func
-	MassDeactivateAndReassign(ctx context.Context, teamName string, userIDs []string) error
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
+	GetReviewCapacity(ctx context.Context, userID string) (*entity.ReviewCapacity, error)
+	CanTakeReview(ctx context.Context, userID string) (bool, error)
+	SetWorkingHours(ctx context.Context, userID, timeZone, workStart, workEnd string) error
 }
 
 type userPGRepository struct {
@@ -42,11 +61,13 @@ func (r *userPGRepository) GetUser(
 ) (*entity.User, error) {
 	var user entity.User
 
-	err := r.db.Pool.QueryRow(ctx,
-		`SELECT user_id, username, team_name, is_active
+	err := r.db.Querier(ctx).QueryRow(ctx,
+		`SELECT user_id, username, team_name, is_active, max_open_reviews,
+		 `+workingHoursColumns+`
 		 FROM users WHERE user_id = $1`,
 		userID,
-	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive,
+		&user.MaxOpenReviews, &user.TimeZone, &user.WorkStart, &user.WorkEnd)
 	if err != nil {
 		return nil, errors.New(string(entity.CodeNotFound))
 	}
@@ -59,7 +80,7 @@ func (r *userPGRepository) SetIsActive(
 	userID string,
 	active bool,
 ) error {
-	result, err := r.db.Pool.Exec(ctx,
+	result, err := r.db.Querier(ctx).Exec(ctx,
 		`UPDATE users SET is_active = $1 WHERE user_id = $2`, active, userID,
 	)
 	if err != nil {
@@ -74,6 +95,91 @@ func (r *userPGRepository) SetIsActive(
 	return nil
 }
 
+func (r *userPGRepository) SetMaxOpenReviews(
+	ctx context.Context,
+	userID string,
+	maxOpenReviews *int,
+) error {
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`UPDATE users SET max_open_reviews = $1 WHERE user_id = $2`,
+		maxOpenReviews, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeNotFound))
+	}
+
+	return nil
+}
+
+func (r *userPGRepository) SetWorkingHours(
+	ctx context.Context,
+	userID, timeZone, workStart, workEnd string,
+) error {
+	result, err := r.db.Querier(ctx).Exec(ctx,
+		`UPDATE users
+		 SET time_zone = $1,
+		     work_start = NULLIF($2, '')::time,
+		     work_end = NULLIF($3, '')::time
+		 WHERE user_id = $4`,
+		timeZone, workStart, workEnd, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	const noRowsAffected = 0
+	if result.RowsAffected() == noRowsAffected {
+		return errors.New(string(entity.CodeNotFound))
+	}
+
+	return nil
+}
+
+func (r *userPGRepository) GetReviewCapacity(
+	ctx context.Context,
+	userID string,
+) (*entity.ReviewCapacity, error) {
+	var (
+		maxOpenReviews *int
+		openReviews    int
+	)
+
+	err := r.db.Querier(ctx).QueryRow(ctx,
+		`SELECT `+effectiveCapacity+`, `+openReviewCount+`
+		 FROM users WHERE user_id = $1`,
+		userID,
+	).Scan(&maxOpenReviews, &openReviews)
+	if err != nil {
+		return nil, errors.New(string(entity.CodeNotFound))
+	}
+
+	return entity.NewReviewCapacity(maxOpenReviews, openReviews), nil
+}
+
+// CanTakeReview applies the automatic candidate filter to a single user: not
+// inside an unavailability window and below their open review limit.
+func (r *userPGRepository) CanTakeReview(ctx context.Context, userID string) (bool, error) {
+	var ok bool
+
+	err := r.db.Querier(ctx).QueryRow(ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM users
+			WHERE user_id = $1`+eligibleReviewerFilter+`
+		)`,
+		userID,
+	).Scan(&ok)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
 func (r *userPGRepository) GetActiveUsersByTeam(
 	ctx context.Context,
 	teamName string,
@@ -84,11 +190,11 @@ func (r *userPGRepository) GetActiveUsersByTeam(
         	user_id,
         	username,
         	team_name,
-        	is_active
+        	is_active,
+        	` + workingHoursColumns + `
     	FROM users
     	WHERE team_name = $1
-      		AND is_active = TRUE
-	`
+      		AND is_active = TRUE` + eligibleReviewerFilter
 	args := []interface{}{teamName}
 
 	const emptySlice = 0
@@ -97,7 +203,7 @@ func (r *userPGRepository) GetActiveUsersByTeam(
 		args = append(args, exclude)
 	}
 
-	rows, err := r.db.Pool.Query(ctx, query, args...)
+	rows, err := r.db.Querier(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +218,9 @@ func (r *userPGRepository) GetActiveUsersByTeam(
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.TimeZone,
+			&user.WorkStart,
+			&user.WorkEnd,
 		); err != nil {
 			return nil, err
 		}
@@ -126,9 +235,10 @@ func (r *userPGRepository) GetPRsForReviewer(
 	ctx context.Context,
 	userID string,
 ) ([]*entity.PullRequestShort, error) {
-	rows, err := r.db.Pool.Query(ctx,
+	rows, err := r.db.Querier(ctx).Query(ctx,
 		//nolint:revive // sql query
-		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+		 COALESCE(pr.repository_name, ''), COALESCE(pr.number, 0)
 		 FROM pr_reviewers prr
 		 JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
 		 WHERE prr.reviewer_id = $1
@@ -148,6 +258,8 @@ func (r *userPGRepository) GetPRsForReviewer(
 			&pr.PullRequestName,
 			&pr.AuthorID,
 			&pr.Status,
+			&pr.RepositoryName,
+			&pr.Number,
 		); err != nil {
 			return nil, err
 		}
@@ -157,162 +269,3 @@ func (r *userPGRepository) GetPRsForReviewer(
 
 	return prs, nil
 }
-
-//nolint:revive // to heavy for coordination func
-func (r *userPGRepository) MassDeactivateAndReassign(
-	ctx context.Context,
-	teamName string,
-	userIDs []string,
-) error {
-	tx, err := r.db.Pool.Begin(ctx)
-	if err != nil {
-		return err
-	}
-	//nolint:errcheck // best-effort
-	defer tx.Rollback(ctx)
-
-	if e := r.deactivateUsers(ctx, tx, teamName, userIDs); e != nil {
-		return e
-	}
-
-	prs, err := r.fetchAffectedPRs(ctx, tx, userIDs)
-	if err != nil {
-		return err
-	}
-
-	for _, pr := range prs {
-		remaining := filterRemainingReviewers(pr.Reviewers, userIDs)
-
-		if err := r.removeReviewersFromPR(ctx, tx, pr.ID, userIDs); err != nil {
-			return err
-		}
-
-		if len(remaining) == 0 {
-			if err := r.assignFallbackReviewer(ctx,
-				tx,
-				pr.ID,
-				teamName,
-				userIDs); err != nil {
-				return err
-			}
-		}
-	}
-
-	return tx.Commit(ctx)
-}
-
-//nolint:revive // useless linter here
-func (r *userPGRepository) deactivateUsers(
-	ctx context.Context,
-	tx pgx.Tx,
-	teamName string,
-	userIDs []string,
-) error {
-	_, err := tx.Exec(ctx,
-		`UPDATE users
-         SET is_active = FALSE
-         WHERE user_id = ANY($1::text[]) AND team_name = $2`,
-		userIDs, teamName,
-	)
-	return err
-}
-
-// PRInfo stores PR ID and current reviewers.
-type PRInfo struct {
-	ID        string
-	Reviewers []string
-}
-
-//nolint:revive // useless linter here
-func (r *userPGRepository) fetchAffectedPRs(
-	ctx context.Context,
-	tx pgx.Tx,
-	userIDs []string,
-) ([]PRInfo, error) {
-	rows, err := tx.Query(ctx,
-		`SELECT pr.pull_request_id,
-                array_agg(prr.reviewer_id ORDER BY prr.assigned_at)
-         FROM pr_reviewers prr
-         JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
-         WHERE pr.status = 'OPEN'
-           AND prr.reviewer_id = ANY($1::text[])
-         GROUP BY pr.pull_request_id`,
-		userIDs,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var result []PRInfo
-	for rows.Next() {
-		var pr PRInfo
-		if err := rows.Scan(&pr.ID, &pr.Reviewers); err != nil {
-			return nil, err
-		}
-		result = append(result, pr)
-	}
-	return result, rows.Err()
-}
-
-func filterRemainingReviewers(reviewers, disabled []string) []string {
-	disabledSet := make(map[string]struct{}, len(disabled))
-	for _, id := range disabled {
-		disabledSet[id] = struct{}{}
-	}
-
-	res := make([]string, initialReviewerCap, len(reviewers))
-	for _, rID := range reviewers {
-		if _, skip := disabledSet[rID]; !skip {
-			res = append(res, rID)
-		}
-	}
-	return res
-}
-
-//nolint:revive // useless linter here
-func (r *userPGRepository) removeReviewersFromPR(
-	ctx context.Context,
-	tx pgx.Tx,
-	prID string,
-	userIDs []string,
-) error {
-	_, err := tx.Exec(ctx,
-		`DELETE FROM pr_reviewers
-         WHERE pull_request_id = $1
-           AND reviewer_id = ANY($2::text[])`,
-		prID, userIDs,
-	)
-	return err
-}
-
-//nolint:revive // useless linter here
-func (r *userPGRepository) assignFallbackReviewer(
-	ctx context.Context,
-	tx pgx.Tx,
-	prID string,
-	teamName string,
-	excluded []string,
-) error {
-	var candidate string
-	err := tx.QueryRow(ctx,
-		`SELECT user_id
-         FROM users
-         WHERE team_name = $1
-           AND is_active = TRUE
-           AND NOT (user_id = ANY($2::text[]))
-         LIMIT 1`,
-		teamName, excluded,
-	).Scan(&candidate)
-
-	if err != nil || candidate == "" {
-		return err
-	}
-
-	_, err = tx.Exec(ctx,
-		`INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-         VALUES ($1, $2)`,
-		prID, candidate,
-	)
-	return err
-}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const (
+	availabilityQueryTimeout = 300 * time.Millisecond
+	handoverTimeout          = 5 * time.Second
+)
+
+type AvailabilityService struct {
+	repo      postgres.AvailabilityRepository
+	userRepo  postgres.UserRepository
+	prRepo    postgres.PullRequestRepository
+	prService *PRService
+}
+
+func NewAvailabilityService(repo postgres.AvailabilityRepository,
+	userRepo postgres.UserRepository,
+	prRepo postgres.PullRequestRepository,
+	prService *PRService) *AvailabilityService {
+	return &AvailabilityService{
+		repo:      repo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		prService: prService,
+	}
+}
+
+//nolint:revive // func
+func (s *AvailabilityService) AddUnavailability(
+	ctx context.Context,
+	window *entity.Unavailability,
+) (*entity.Unavailability, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, availabilityQueryTimeout)
+	defer cancel()
+
+	if _, err := s.userRepo.GetUser(queryCtx, window.UserID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	if err := s.repo.AddUnavailability(queryCtx, window); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (s *AvailabilityService) ListUnavailability(
+	ctx context.Context,
+	userID string,
+) ([]*entity.Unavailability, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, availabilityQueryTimeout)
+	defer cancel()
+
+	if _, err := s.userRepo.GetUser(queryCtx, userID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	return s.repo.ListUnavailability(queryCtx, userID)
+}
+
+func (s *AvailabilityService) DeleteUnavailability(ctx context.Context, id int64) error {
+	queryCtx, cancel := context.WithTimeout(ctx, availabilityQueryTimeout)
+	defer cancel()
+
+	if err := s.repo.DeleteUnavailability(queryCtx, id); err != nil {
+		if err.Error() == notFoundErr {
+			return entity.ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ProcessStartedWindows hands over the open reviews of users whose window
+// with handover enabled has just started. Eligibility is restored without any
+// extra work once a window ends, because candidate queries only skip windows
+// covering the current moment. A window is marked handed over only once the
+// user holds none of its reviews, so reviews without a replacement are retried
+// on every tick until the window ends.
+//
+//nolint:revive // func
+func (s *AvailabilityService) ProcessStartedWindows(ctx context.Context) (int, error) {
+	jobCtx, cancel := context.WithTimeout(ctx, handoverTimeout)
+	defer cancel()
+
+	windows, err := s.repo.GetStartedHandovers(jobCtx)
+	if err != nil {
+		return Empty, err
+	}
+
+	var errs []error
+
+	handled := Empty
+
+	for _, window := range windows {
+		prIDs, err := s.prRepo.GetOpenPRsByReviewer(jobCtx, window.UserID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		failed := false
+		held := false
+
+		for _, prID := range prIDs {
+			_, _, err := s.prService.ReassignReviewer(jobCtx, prID, window.UserID)
+
+			switch {
+			case errors.Is(err, entity.ErrNoCandidate):
+				// no replacement keeps the reviewer assigned rather than
+				// leaving the PR without anyone
+				held = true
+			case err != nil:
+				errs = append(errs, err)
+				failed = true
+			}
+		}
+
+		// retried on the next tick
+		if failed || held {
+			continue
+		}
+
+		if err := s.repo.MarkHandedOver(jobCtx, window.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		handled++
+	}
+
+	return handled, errors.Join(errs...)
+}
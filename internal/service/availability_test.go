@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestAvailabilityService_AddUnavailability(t *testing.T) {
+	t.Run("unknown user returns NOT_FOUND", func(t *testing.T) {
+		repo := new(MockAvailabilityRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetUser", mock.Anything, "ghost").Return(nil, errors.New("NOT_FOUND"))
+
+		svc := NewAvailabilityService(repo, userRepo, new(MockPullRequestRepository), nil)
+
+		window, err := svc.AddUnavailability(t.Context(), &entity.Unavailability{UserID: "ghost"})
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, window)
+		repo.AssertNotCalled(t, "AddUnavailability", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stores window", func(t *testing.T) {
+		repo := new(MockAvailabilityRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetUser", mock.Anything, "u1").Return(&entity.User{UserID: "u1"}, nil)
+		repo.On("AddUnavailability", mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewAvailabilityService(repo, userRepo, new(MockPullRequestRepository), nil)
+
+		now := time.Now()
+		window, err := svc.AddUnavailability(t.Context(), &entity.Unavailability{
+			UserID:   "u1",
+			StartsAt: now,
+			EndsAt:   now.Add(7 * 24 * time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "u1", window.UserID)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAvailabilityService_ProcessStartedWindows(t *testing.T) {
+	t.Run("reassigns open reviews and marks window", func(t *testing.T) {
+		repo := new(MockAvailabilityRepository)
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, userRepo, new(MockTeamRepository))
+
+		repo.On("GetStartedHandovers", mock.Anything).Return([]*entity.Unavailability{
+			{ID: 1, UserID: "u2", Handover: true},
+		}, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u2").Return([]string{"pr1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "u1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").Return(&entity.User{UserID: "u2", TeamName: "team1"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"u1", "u2"}).
+			Return([]*entity.User{{UserID: "u3", TeamName: "team1", IsActive: true}}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"u3"}).Return(nil)
+		repo.On("MarkHandedOver", mock.Anything, int64(1)).Return(nil)
+
+		svc := NewAvailabilityService(repo, userRepo, prRepo, prService)
+
+		handled, err := svc.ProcessStartedWindows(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+
+		repo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("review without a replacement leaves window for retry", func(t *testing.T) {
+		repo := new(MockAvailabilityRepository)
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, userRepo, new(MockTeamRepository))
+
+		repo.On("GetStartedHandovers", mock.Anything).Return([]*entity.Unavailability{
+			{ID: 3, UserID: "u2", Handover: true},
+		}, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u2").Return([]string{"pr1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "u1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").Return(&entity.User{UserID: "u2", TeamName: "team1"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"u1", "u2"}).
+			Return([]*entity.User{}, nil)
+
+		svc := NewAvailabilityService(repo, userRepo, prRepo, prService)
+
+		handled, err := svc.ProcessStartedWindows(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, handled)
+		repo.AssertNotCalled(t, "MarkHandedOver", mock.Anything, mock.Anything)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed reassignment leaves window for retry", func(t *testing.T) {
+		repo := new(MockAvailabilityRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, new(MockUserRepository), new(MockTeamRepository))
+
+		repo.On("GetStartedHandovers", mock.Anything).Return([]*entity.Unavailability{
+			{ID: 2, UserID: "u2", Handover: true},
+		}, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u2").Return([]string{"pr1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(nil, errors.New("NOT_FOUND"))
+
+		svc := NewAvailabilityService(repo, new(MockUserRepository), prRepo, prService)
+
+		handled, err := svc.ProcessStartedWindows(t.Context())
+		assert.Error(t, err)
+		assert.Equal(t, 0, handled)
+		repo.AssertNotCalled(t, "MarkHandedOver", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const conflictQueryTimeout = 300 * time.Millisecond
+
+// WithConflicts keeps users paired by a conflict rule off each other's PRs.
+func WithConflicts(r postgres.ConflictRepository) PROption {
+	return func(s *PRService) {
+		s.conflictRepo = r
+	}
+}
+
+// conflictsOf returns the users who must not review authorID's PRs.
+func (s *PRService) conflictsOf(ctx context.Context, authorID string) ([]string, error) {
+	if s.conflictRepo == nil {
+		return nil, nil
+	}
+
+	return s.conflictRepo.GetConflictingUsers(ctx, authorID)
+}
+
+// ConflictService manages the pairs of users who never review each other.
+// Rules only affect later assignments; reviewers already on a PR stay.
+type ConflictService struct {
+	repo     postgres.ConflictRepository
+	userRepo postgres.UserRepository
+}
+
+func NewConflictService(
+	repo postgres.ConflictRepository,
+	userRepo postgres.UserRepository,
+) *ConflictService {
+	return &ConflictService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// AddConflict stores the pair; adding an existing pair updates its reason.
+func (s *ConflictService) AddConflict(
+	ctx context.Context,
+	conflict *entity.ReviewerConflict,
+) (*entity.ReviewerConflict, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, conflictQueryTimeout)
+	defer cancel()
+
+	for _, id := range []string{conflict.UserID, conflict.OtherUserID} {
+		if _, err := s.userRepo.GetUser(queryCtx, id); err != nil {
+			return nil, entity.ErrNotFound
+		}
+	}
+
+	if err := s.repo.AddConflict(queryCtx, conflict); err != nil {
+		return nil, err
+	}
+
+	return conflict, nil
+}
+
+func (s *ConflictService) DeleteConflict(ctx context.Context, userID, otherUserID string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, conflictQueryTimeout)
+	defer cancel()
+
+	if err := s.repo.DeleteConflict(queryCtx, userID, otherUserID); err != nil {
+		if err.Error() == notFoundErr {
+			return entity.ErrNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ListConflicts returns the pairs involving userID, or all of them when it
+// is empty.
+func (s *ConflictService) ListConflicts(
+	ctx context.Context,
+	userID string,
+) ([]*entity.ReviewerConflict, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, conflictQueryTimeout)
+	defer cancel()
+
+	if userID != emptyString {
+		if _, err := s.userRepo.GetUser(queryCtx, userID); err != nil {
+			return nil, entity.ErrNotFound
+		}
+	}
+
+	return s.repo.ListConflicts(queryCtx, userID)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestConflictService(t *testing.T) {
+	t.Run("adding a pair with an unknown user returns NOT_FOUND", func(t *testing.T) {
+		repo := new(MockConflictRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetUser", mock.Anything, "u1").Return(&entity.User{UserID: "u1"}, nil)
+		userRepo.On("GetUser", mock.Anything, "ghost").Return(nil, errors.New("NOT_FOUND"))
+
+		svc := NewConflictService(repo, userRepo)
+
+		_, err := svc.AddConflict(t.Context(), &entity.ReviewerConflict{UserID: "u1", OtherUserID: "ghost"})
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		repo.AssertNotCalled(t, "AddConflict", mock.Anything, mock.Anything)
+	})
+
+	t.Run("adds pair", func(t *testing.T) {
+		repo := new(MockConflictRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetUser", mock.Anything, "u1").Return(&entity.User{UserID: "u1"}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").Return(&entity.User{UserID: "u2"}, nil)
+		repo.On("AddConflict", mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewConflictService(repo, userRepo)
+
+		conflict, err := svc.AddConflict(t.Context(), &entity.ReviewerConflict{
+			UserID:      "u1",
+			OtherUserID: "u2",
+			Reason:      "manager",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "manager", conflict.Reason)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("removing a missing pair returns NOT_FOUND", func(t *testing.T) {
+		repo := new(MockConflictRepository)
+		repo.On("DeleteConflict", mock.Anything, "u1", "u2").Return(errors.New("NOT_FOUND"))
+
+		svc := NewConflictService(repo, new(MockUserRepository))
+
+		assert.ErrorIs(t, svc.DeleteConflict(t.Context(), "u1", "u2"), entity.ErrNotFound)
+	})
+
+	t.Run("listing every pair skips the user lookup", func(t *testing.T) {
+		repo := new(MockConflictRepository)
+		userRepo := new(MockUserRepository)
+		repo.On("ListConflicts", mock.Anything, "").
+			Return([]*entity.ReviewerConflict{{UserID: "u1", OtherUserID: "u2"}}, nil)
+
+		svc := NewConflictService(repo, userRepo)
+
+		conflicts, err := svc.ListConflicts(t.Context(), "")
+		require.NoError(t, err)
+		assert.Len(t, conflicts, 1)
+		userRepo.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPRService_Conflicts(t *testing.T) {
+	team := &entity.Team{
+		TeamName: "team1",
+		Members: []entity.TeamMember{
+			{UserID: "author", IsActive: true},
+			{UserID: "manager", IsActive: true},
+			{UserID: "peer", IsActive: true},
+		},
+	}
+
+	newService := func() (*PRService, *MockPullRequestRepository, *MockUserRepository) {
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+		teamRepo := new(MockTeamRepository)
+		conflictRepo := new(MockConflictRepository)
+
+		userRepo.On("GetUser", mock.Anything, "author").
+			Return(&entity.User{UserID: "author", TeamName: "team1", IsActive: true}, nil)
+		teamRepo.On("GetTeam", mock.Anything, "team1").Return(team, nil)
+		conflictRepo.On("GetConflictingUsers", mock.Anything, "author").Return([]string{"manager"}, nil)
+
+		return NewPRService(prRepo, userRepo, teamRepo, WithConflicts(conflictRepo)), prRepo, userRepo
+	}
+
+	t.Run("creation excludes conflicting users from selection", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		prRepo.On("PRExists", mock.Anything, "pr-1").Return(false, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "manager"}).
+			Return([]*entity.User{{UserID: "peer"}}, nil)
+		prRepo.On("CreatePR", mock.Anything, mock.Anything, []string{"peer"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AssignedReviewers: []string{"peer"},
+		}, nil)
+
+		_, _, err := s.CreatePR(t.Context(), entity.PRCreateParams{PullRequestID: "pr-1", AuthorID: "author"})
+		require.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("conflicting required reviewer is rejected", func(t *testing.T) {
+		s, prRepo, _ := newService()
+
+		prRepo.On("PRExists", mock.Anything, "pr-1").Return(false, nil)
+
+		_, _, err := s.CreatePR(t.Context(), entity.PRCreateParams{
+			PullRequestID:     "pr-1",
+			AuthorID:          "author",
+			RequiredReviewers: []string{"manager"},
+		})
+		assert.ErrorIs(t, err, entity.ErrInvalidReviewer)
+		prRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassignment excludes conflicting users", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "author",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"peer"},
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "peer").
+			Return(&entity.User{UserID: "peer", TeamName: "team1", IsActive: true}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "peer", "manager"}).
+			Return([]*entity.User{}, nil)
+
+		_, _, err := s.ReassignReviewer(t.Context(), "pr-1", "peer")
+		assert.ErrorIs(t, err, entity.ErrNoCandidate)
+		userRepo.AssertCalled(t, "GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "peer", "manager"})
+	})
+
+	t.Run("explicitly added conflicting reviewer is rejected", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID: "pr-1",
+			AuthorID:      "author",
+			Status:        entity.OPEN,
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "manager").
+			Return(&entity.User{UserID: "manager", TeamName: "team1", IsActive: true}, nil)
+
+		_, _, err := s.AddReviewer(t.Context(), "pr-1", "manager")
+		assert.ErrorIs(t, err, entity.ErrInvalidReviewer)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
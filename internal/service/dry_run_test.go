@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
+)
+
+func TestPRService_WithinTxDryRun(t *testing.T) {
+	tx := &MockTransactor{}
+	s := NewPRService(nil, nil, nil, WithTransactor(tx))
+
+	ran := false
+	err := s.withinTx(t.Context(), true, func(_ context.Context) error {
+		ran = true
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, tx.Calls)
+
+	err = s.withinTx(t.Context(), true, func(_ context.Context) error {
+		return entity.ErrNoCandidate
+	})
+	assert.ErrorIs(t, err, entity.ErrNoCandidate)
+}
+
+func TestPRService_WithinTxObservesCommittedPicks(t *testing.T) {
+	ctx := t.Context()
+	s := NewPRService(nil, nil, nil, WithTransactor(&MockTransactor{}))
+
+	// every case uses its own operation label, the counters are global
+	assigned := func(operation string) float64 {
+		return testutil.ToFloat64(metrics.AssignmentOutcomes.WithLabelValues(operation, metrics.OutcomeAssigned))
+	}
+	observe := func(operation string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			observeAssignment(ctx, &entity.AssignmentDecision{Operation: operation, Picked: []string{"u1"}})
+			return nil
+		}
+	}
+
+	require.NoError(t, s.withinTx(ctx, true, observe("dry")))
+	assert.Zero(t, assigned("dry"), "dry runs are not counted")
+
+	require.NoError(t, s.withinTx(ctx, true, func(ctx context.Context) error {
+		return s.withinTx(ctx, false, observe("planned"))
+	}))
+	assert.Zero(t, assigned("planned"), "a commit nested in a dry run is not counted")
+
+	err := s.withinTx(ctx, false, func(ctx context.Context) error {
+		_ = observe("rolled-back")(ctx)
+		return entity.ErrNoCandidate
+	})
+	assert.ErrorIs(t, err, entity.ErrNoCandidate)
+	assert.Zero(t, assigned("rolled-back"), "rolled back changes are not counted")
+
+	require.NoError(t, s.withinTx(ctx, false, func(ctx context.Context) error {
+		return s.withinTx(ctx, false, observe("committed"))
+	}))
+	assert.InDelta(t, 1, assigned("committed"), 0)
+}
+
+func TestPRService_PlanReassign(t *testing.T) {
+	prRepo := new(MockPullRequestRepository)
+	userRepo := new(MockUserRepository)
+	s := NewPRService(prRepo, userRepo, new(MockTeamRepository), WithTransactor(&MockTransactor{}))
+
+	prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "a1",
+		Status:            entity.OPEN,
+		AssignedReviewers: []string{"u1", "u2"},
+	}, nil).Twice()
+	userRepo.On("GetUser", mock.Anything, "u1").Return(&entity.User{UserID: "u1", TeamName: "team1"}, nil)
+	userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"a1", "u1"}).
+		Return([]*entity.User{{UserID: "u3"}}, nil)
+	prRepo.On("UpdateReviewers", mock.Anything, "pr-1", []string{"u3", "u2"}).Return(nil)
+	prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "a1",
+		Status:            entity.OPEN,
+		AssignedReviewers: []string{"u3", "u2"},
+	}, nil)
+
+	pr, replacedBy, report, err := s.PlanReassign(t.Context(), "pr-1", "u1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "u3", replacedBy)
+	assert.Equal(t, []string{"u3", "u2"}, pr.AssignedReviewers)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.PullRequests, 1)
+	assert.Equal(t, []string{"u1"}, report.PullRequests[0].Removed)
+	assert.Equal(t, []string{"u3"}, report.PullRequests[0].Added)
+	assert.Empty(t, report.Understaffed)
+}
+
+func TestUserService_PlanStatusChange(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("activation plans no reassignment", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		svc := NewUserService(userRepo, nil, nil, nil)
+		userRepo.On("GetUser", mock.Anything, "u1").Return(&entity.User{UserID: "u1", IsActive: false}, nil)
+
+		user, report, err := svc.PlanStatusChange(ctx, "u1", true)
+		require.NoError(t, err)
+
+		assert.True(t, user.IsActive)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, report.PullRequests)
+		userRepo.AssertNotCalled(t, "SetIsActive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("deactivation plans the reassignment", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, userRepo, nil, WithTransactor(&MockTransactor{}))
+		svc := NewUserService(userRepo, prRepo, nil, prService)
+
+		userRepo.On("GetUser", mock.Anything, "u1").
+			Return(&entity.User{UserID: "u1", TeamName: "team1", IsActive: true}, nil)
+		userRepo.On("SetIsActive", mock.Anything, "u1", false).Return(nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "a1",
+			AssignedReviewers: []string{"u1"},
+		}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"a1", "u1", "u1"}).
+			Return([]*entity.User{}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-1", []string{}).Return(nil)
+
+		user, report, err := svc.PlanStatusChange(ctx, "u1", false)
+		require.NoError(t, err)
+
+		assert.False(t, user.IsActive)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []string{"pr-1"}, report.WithoutReviewers)
+	})
+
+	t.Run("unknown user returns NOT_FOUND", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		svc := NewUserService(userRepo, nil, nil, nil)
+		userRepo.On("GetUser", mock.Anything, "u1").Return(nil, errors.New("NOT_FOUND"))
+
+		_, _, err := svc.PlanStatusChange(ctx, "u1", false)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+// ExplainAssignment returns the recorded automatic picks of a PR, each with
+// the random order replayed from its seed, so it shows why a candidate came
+// first: the shuffle itself or the ranking applied to it.
+func (s *PRService) ExplainAssignment(
+	ctx context.Context,
+	prID string,
+) (*entity.AssignmentExplanation, error) {
+	ctx, span := tracing.Start(ctx, "PRService.ExplainAssignment")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
+	defer cancel()
+
+	pr, err := s.repo.GetPR(queryCtx, prID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	explanation := &entity.AssignmentExplanation{
+		PullRequestID:     pr.PullRequestID,
+		AssignedReviewers: pr.AssignedReviewers,
+		Decisions:         []*entity.AssignmentDecision{},
+	}
+
+	if s.decisionRepo == nil {
+		return explanation, nil
+	}
+
+	decisions, err := s.decisionRepo.ListDecisions(queryCtx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decision := range decisions {
+		decision.Shuffled = replayShuffle(decision.Seed, decision.Candidates)
+	}
+
+	explanation.Decisions = decisions
+
+	return explanation, nil
+}
+
+// replayShuffle reorders candidates the way pickReviewers shuffled them for
+// seed.
+func replayShuffle(seed int64, candidates []string) []string {
+	shuffled := append([]string{}, candidates...)
+	shuffleWithSeed(seed, len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled
+}
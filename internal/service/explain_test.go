@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestPRService_SeededSelection(t *testing.T) {
+	candidates := []*entity.User{
+		{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}, {UserID: "u5"},
+	}
+
+	t.Run("same source gives the same picks", func(t *testing.T) {
+		first := NewPRService(nil, nil, nil, WithRandSource(rand.NewSource(42)))
+		second := NewPRService(nil, nil, nil, WithRandSource(rand.NewSource(42)))
+
+		for range 10 {
+			picked1, decision1 := first.pickReviewers(operationCreate, candidates, 2, nil)
+			picked2, decision2 := second.pickReviewers(operationCreate, candidates, 2, nil)
+
+			assert.Equal(t, picked1, picked2)
+			assert.Equal(t, decision1.Seed, decision2.Seed)
+		}
+	})
+
+	t.Run("recorded seed replays the order", func(t *testing.T) {
+		s := NewPRService(nil, nil, nil)
+
+		picked, decision := s.pickReviewers(operationCreate, candidates, 2, nil)
+
+		assert.Equal(t, []string{"u1", "u2", "u3", "u4", "u5"}, decision.Candidates)
+		assert.Equal(t, decision.Ranking, replayShuffle(decision.Seed, decision.Candidates))
+		assert.Equal(t, userIDs(picked), decision.Picked)
+	})
+}
+
+func TestPRService_RecordsDecisions(t *testing.T) {
+	prRepo := new(MockPullRequestRepository)
+	userRepo := new(MockUserRepository)
+	teamRepo := new(MockTeamRepository)
+	decisionRepo := new(MockDecisionRepository)
+	tx := &MockTransactor{}
+
+	prRepo.On("PRExists", mock.Anything, "pr-1").Return(false, nil)
+	userRepo.On("GetUser", mock.Anything, "author").
+		Return(&entity.User{UserID: "author", TeamName: "team1"}, nil)
+	teamRepo.On("GetTeam", mock.Anything, "team1").Return(&entity.Team{TeamName: "team1"}, nil)
+	userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author"}).
+		Return([]*entity.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}}, nil)
+	prRepo.On("CreatePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{PullRequestID: "pr-1"}, nil)
+	decisionRepo.On("AddDecision", mock.Anything, mock.MatchedBy(func(d *entity.AssignmentDecision) bool {
+		return d.PullRequestID == "pr-1" &&
+			d.Operation == operationCreate &&
+			len(d.Candidates) == 3 &&
+			len(d.Picked) == maxReviewers
+	})).Return(nil).Once()
+
+	s := NewPRService(prRepo, userRepo, teamRepo,
+		WithTransactor(tx),
+		WithDecisions(decisionRepo),
+		WithRandSource(rand.NewSource(7)))
+
+	_, _, err := s.CreatePR(t.Context(), entity.PRCreateParams{PullRequestID: "pr-1", AuthorID: "author"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tx.Calls)
+	decisionRepo.AssertExpectations(t)
+}
+
+func TestPRService_ExplainAssignment(t *testing.T) {
+	t.Run("unknown PR returns NOT_FOUND", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		prRepo.On("GetPR", mock.Anything, "ghost").Return(nil, errors.New("NOT_FOUND"))
+
+		s := NewPRService(prRepo, nil, nil, WithDecisions(new(MockDecisionRepository)))
+
+		_, err := s.ExplainAssignment(t.Context(), "ghost")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+
+	t.Run("decisions come with the replayed order", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		decisionRepo := new(MockDecisionRepository)
+
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+		decisionRepo.On("ListDecisions", mock.Anything, "pr-1").Return([]*entity.AssignmentDecision{
+			{PullRequestID: "pr-1", Seed: 99, Candidates: []string{"u1", "u2", "u3"}},
+		}, nil)
+
+		s := NewPRService(prRepo, nil, nil, WithDecisions(decisionRepo))
+
+		explanation, err := s.ExplainAssignment(t.Context(), "pr-1")
+		require.NoError(t, err)
+
+		require.Len(t, explanation.Decisions, 1)
+		assert.Equal(t, []string{"u2"}, explanation.AssignedReviewers)
+		assert.Equal(t, replayShuffle(99, []string{"u1", "u2", "u3"}), explanation.Decisions[0].Shuffled)
+		assert.ElementsMatch(t, []string{"u1", "u2", "u3"}, explanation.Decisions[0].Shuffled)
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+// DefaultFairnessThreshold flags members whose assignments differ from the
+// expected number by more than half.
+const DefaultFairnessThreshold = 0.5
+
+// GetFairness compares review assignments of a team's members over the
+// period. Members currently inactive are reported but neither counted in the
+// expected split nor listed as outliers.
+func (s *StatsService) GetFairness(
+	ctx context.Context,
+	teamName string,
+	from, to time.Time,
+	threshold float64,
+) (*entity.FairnessReport, error) {
+	members, err := s.statsRepo.GetFairnessData(ctx, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == Empty {
+		return nil, entity.ErrNotFound
+	}
+
+	report := &entity.FairnessReport{
+		From:        from,
+		To:          to,
+		TeamName:    teamName,
+		Members:     members,
+		Overloaded:  []string{},
+		Underloaded: []string{},
+		Threshold:   threshold,
+	}
+
+	var activeDays float64
+
+	counts := make([]float64, 0, len(members))
+
+	for _, member := range members {
+		member.ActiveDays = math.Max(member.ActiveDays, 0)
+		report.TotalAssignments += member.Assignments
+
+		if member.IsActive {
+			activeDays += member.ActiveDays
+			counts = append(counts, float64(member.Assignments))
+		}
+	}
+
+	report.Gini = gini(counts)
+
+	if activeDays == 0 {
+		return report, nil
+	}
+
+	for _, member := range members {
+		if !member.IsActive {
+			continue
+		}
+
+		member.Expected = float64(report.TotalAssignments) * member.ActiveDays / activeDays
+		if member.Expected == 0 {
+			continue
+		}
+
+		member.Deviation = (float64(member.Assignments) - member.Expected) / member.Expected
+
+		switch {
+		case member.Deviation > threshold:
+			report.Overloaded = append(report.Overloaded, member.UserID)
+		case member.Deviation < -threshold:
+			report.Underloaded = append(report.Underloaded, member.UserID)
+		}
+	}
+
+	return report, nil
+}
+
+// gini returns the Gini coefficient of values: 0 for a perfectly even split,
+// approaching 1 when one member gets everything.
+func gini(values []float64) float64 {
+	n := len(values)
+	if n == Empty {
+		return 0
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+
+	if sum == 0 {
+		return 0
+	}
+
+	return (2*weighted)/(float64(n)*sum) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestGini(t *testing.T) {
+	assert.InDelta(t, 0, gini([]float64{3, 3, 3}), 1e-9)
+	assert.InDelta(t, 0, gini(nil), 1e-9)
+	assert.InDelta(t, 0, gini([]float64{0, 0}), 1e-9)
+	assert.InDelta(t, 0.75, gini([]float64{0, 0, 0, 8}), 1e-9)
+}
+
+func TestStatsService_GetFairness(t *testing.T) {
+	from := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	t.Run("expected share follows active days", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		mockRepo.On("GetFairnessData", mock.Anything, "team1", from, to).
+			Return([]*entity.FairnessMember{
+				{UserID: "busy", IsActive: true, Assignments: 10, ActiveDays: 10},
+				{UserID: "even", IsActive: true, Assignments: 5, ActiveDays: 10},
+				{UserID: "idle", IsActive: true, Assignments: 0, ActiveDays: 10},
+				{UserID: "vacation", IsActive: true, Assignments: 0, ActiveDays: 0},
+				{UserID: "left", IsActive: false, Assignments: 1, ActiveDays: 10},
+			}, nil)
+
+		svc := NewStatsService(mockRepo)
+
+		report, err := svc.GetFairness(t.Context(), "team1", from, to, DefaultFairnessThreshold)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, report.TotalAssignments)
+		assert.Equal(t, []string{"busy"}, report.Overloaded)
+		assert.Equal(t, []string{"idle"}, report.Underloaded)
+		assert.Greater(t, report.Gini, 0.0)
+
+		byID := make(map[string]*entity.FairnessMember)
+		for _, m := range report.Members {
+			byID[m.UserID] = m
+		}
+
+		assert.InDelta(t, 16.0/3, byID["even"].Expected, 1e-9)
+		assert.Zero(t, byID["vacation"].Expected)
+		assert.Zero(t, byID["left"].Expected)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		mockRepo.On("GetFairnessData", mock.Anything, "ghost", from, to).
+			Return([]*entity.FairnessMember{}, nil)
+
+		svc := NewStatsService(mockRepo)
+
+		_, err := svc.GetFairness(t.Context(), "ghost", from, to, DefaultFairnessThreshold)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+// Handover moves every open review of fromUserID to the given delegates in
+// one transaction. Delegates must be active. They take the PRs in turn; each
+// PR goes to the next delegate who is neither its author, in conflict with
+// the author nor already assigned, and who passes the capacity and
+// unavailability filter of automatic assignment. The whole handover fails if
+// no delegate fits. The reviewer's own status is left as it is.
+func (s *UserService) Handover(
+	ctx context.Context,
+	fromUserID string,
+	delegateIDs []string,
+	dryRun bool,
+) (*entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Handover")
+	defer span.End()
+
+	if len(delegateIDs) == Empty {
+		return nil, entity.ErrEmptyRequest
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, massDeactivateTimeout)
+	defer cancel()
+
+	if _, err := s.repo.GetUser(queryCtx, fromUserID); err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	if err := s.validateDelegates(queryCtx, fromUserID, delegateIDs); err != nil {
+		return nil, err
+	}
+
+	report := entity.NewReassignmentReport()
+	report.DryRun = dryRun
+
+	err := s.prService.withinTx(queryCtx, dryRun, func(ctx context.Context) error {
+		prIDs, err := s.prService.openPRsReviewedBy(ctx, []string{fromUserID})
+		if err != nil {
+			return err
+		}
+
+		next := 0
+
+		for _, prID := range prIDs {
+			pr, err := s.prRepo.GetPR(ctx, prID)
+			if err != nil {
+				return entity.ErrNotFound
+			}
+
+			conflicting, err := s.prService.conflictsOf(ctx, pr.AuthorID)
+			if err != nil {
+				return err
+			}
+
+			delegate := -1
+
+			for i := range delegateIDs {
+				candidate := (next + i) % len(delegateIDs)
+				id := delegateIDs[candidate]
+
+				if id == pr.AuthorID ||
+					slices.Contains(pr.AssignedReviewers, id) ||
+					slices.Contains(conflicting, id) {
+					continue
+				}
+
+				// checked per PR: the reviews handed over so far count
+				// towards the delegate's limit inside the transaction
+				ok, err := s.repo.CanTakeReview(ctx, id)
+				if err != nil {
+					return err
+				}
+
+				if ok {
+					delegate = candidate
+					break
+				}
+			}
+
+			if delegate < 0 {
+				return fmt.Errorf("%w: no delegate can review %s: author, conflicting, already assigned, "+
+					"unavailable or at their review limit", entity.ErrInvalidDelegate, prID)
+			}
+
+			next = delegate + 1
+
+			reviewers := make([]string, len(pr.AssignedReviewers))
+			for i, id := range pr.AssignedReviewers {
+				reviewers[i] = id
+				if id == fromUserID {
+					reviewers[i] = delegateIDs[delegate]
+				}
+			}
+
+			if err := s.prRepo.UpdateReviewers(ctx, prID, reviewers); err != nil {
+				return err
+			}
+
+			report.Add(entity.DiffReviewers(prID, pr.AssignedReviewers, reviewers))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *UserService) validateDelegates(
+	ctx context.Context,
+	fromUserID string,
+	delegateIDs []string,
+) error {
+	seen := make(map[string]bool, len(delegateIDs))
+
+	for _, id := range delegateIDs {
+		switch {
+		case id == fromUserID:
+			return fmt.Errorf("%w: cannot hand over to %s themselves", entity.ErrInvalidDelegate, id)
+		case seen[id]:
+			return fmt.Errorf("%w: duplicate delegate %s", entity.ErrInvalidDelegate, id)
+		}
+
+		seen[id] = true
+
+		delegate, err := s.repo.GetUser(ctx, id)
+		if err != nil {
+			return entity.ErrNotFound
+		}
+
+		if !delegate.IsActive {
+			return fmt.Errorf("%w: delegate %s is not active", entity.ErrInvalidDelegate, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestUserService_Handover(t *testing.T) {
+	ctx := t.Context()
+
+	newService := func() (*UserService, *MockUserRepository, *MockPullRequestRepository, *MockTransactor) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		tx := &MockTransactor{}
+		prService := NewPRService(prRepo, userRepo, nil, WithTransactor(tx))
+
+		return NewUserService(userRepo, prRepo, nil, prService), userRepo, prRepo, tx
+	}
+
+	active := func(id string) *entity.User {
+		return &entity.User{UserID: id, TeamName: "team1", IsActive: true}
+	}
+
+	t.Run("delegates take PRs in turn, skipping authors and assigned reviewers", func(t *testing.T) {
+		svc, userRepo, prRepo, tx := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d1").Return(active("d1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d2").Return(active("d2"), nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1", "pr-2", "pr-3"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID: "pr-1", AuthorID: "a1", AssignedReviewers: []string{"u1", "r1"},
+		}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-2").Return(&entity.PullRequest{
+			PullRequestID: "pr-2", AuthorID: "d2", AssignedReviewers: []string{"u1"},
+		}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-3").Return(&entity.PullRequest{
+			PullRequestID: "pr-3", AuthorID: "a1", AssignedReviewers: []string{"u1"},
+		}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-1", []string{"d1", "r1"}).Return(nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-2", []string{"d1"}).Return(nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-3", []string{"d2"}).Return(nil)
+		userRepo.On("CanTakeReview", mock.Anything, mock.Anything).Return(true, nil)
+
+		report, err := svc.Handover(ctx, "u1", []string{"d1", "d2"}, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, tx.Calls)
+		require.Len(t, report.PullRequests, 3)
+		assert.Equal(t, []string{"d1"}, report.PullRequests[1].Added)
+		assert.Empty(t, report.Understaffed)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("single delegate already assigned fails the handover", func(t *testing.T) {
+		svc, userRepo, prRepo, _ := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d1").Return(active("d1"), nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID: "pr-1", AuthorID: "a1", AssignedReviewers: []string{"u1", "d1"},
+		}, nil)
+
+		_, err := svc.Handover(ctx, "u1", []string{"d1"}, false)
+		assert.ErrorIs(t, err, entity.ErrInvalidDelegate)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delegate who cannot take a review is skipped", func(t *testing.T) {
+		svc, userRepo, prRepo, _ := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d1").Return(active("d1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d2").Return(active("d2"), nil)
+		userRepo.On("CanTakeReview", mock.Anything, "d1").Return(false, nil)
+		userRepo.On("CanTakeReview", mock.Anything, "d2").Return(true, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID: "pr-1", AuthorID: "a1", AssignedReviewers: []string{"u1"},
+		}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-1", []string{"d2"}).Return(nil)
+
+		_, err := svc.Handover(ctx, "u1", []string{"d1", "d2"}, false)
+		require.NoError(t, err)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("no delegate with capacity fails the handover", func(t *testing.T) {
+		svc, userRepo, prRepo, _ := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d1").Return(active("d1"), nil)
+		userRepo.On("CanTakeReview", mock.Anything, "d1").Return(false, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID: "pr-1", AuthorID: "a1", AssignedReviewers: []string{"u1"},
+		}, nil)
+
+		_, err := svc.Handover(ctx, "u1", []string{"d1"}, false)
+		assert.ErrorIs(t, err, entity.ErrInvalidDelegate)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("inactive delegate is rejected", func(t *testing.T) {
+		svc, userRepo, _, tx := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+		userRepo.On("GetUser", mock.Anything, "d1").
+			Return(&entity.User{UserID: "d1", IsActive: false}, nil)
+
+		_, err := svc.Handover(ctx, "u1", []string{"d1"}, false)
+		assert.ErrorIs(t, err, entity.ErrInvalidDelegate)
+		assert.Zero(t, tx.Calls)
+	})
+
+	t.Run("handing over to oneself is rejected", func(t *testing.T) {
+		svc, userRepo, _, _ := newService()
+		userRepo.On("GetUser", mock.Anything, "u1").Return(active("u1"), nil)
+
+		_, err := svc.Handover(ctx, "u1", []string{"u1"}, false)
+		assert.ErrorIs(t, err, entity.ErrInvalidDelegate)
+	})
+
+	t.Run("unknown reviewer returns NOT_FOUND", func(t *testing.T) {
+		svc, userRepo, _, _ := newService()
+		userRepo.On("GetUser", mock.Anything, "u1").Return(nil, errors.New("NOT_FOUND"))
+
+		_, err := svc.Handover(ctx, "u1", []string{"d1"}, false)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
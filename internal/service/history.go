@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const (
+	snapshotTimeout     = 5 * time.Second
+	historyQueryTimeout = 500 * time.Millisecond
+)
+
+type HistoryService struct {
+	repo postgres.SnapshotRepository
+	now  func() time.Time
+}
+
+func NewHistoryService(repo postgres.SnapshotRepository) *HistoryService {
+	return &HistoryService{repo: repo, now: time.Now}
+}
+
+// TakeSnapshot stores today's aggregates (UTC day).
+func (s *HistoryService) TakeSnapshot(ctx context.Context) error {
+	jobCtx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	return s.repo.TakeSnapshot(jobCtx, s.now().UTC())
+}
+
+// GetHistory returns one series per subject for the metric between from and
+// to inclusive; subject narrows per-user metrics to a single user.
+func (s *HistoryService) GetHistory(
+	ctx context.Context,
+	metric entity.StatsMetric,
+	subject string,
+	from, to time.Time,
+) (*entity.StatsHistory, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, historyQueryTimeout)
+	defer cancel()
+
+	points, err := s.repo.GetHistory(queryCtx, metric, subject, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &entity.StatsHistory{
+		From:   from,
+		To:     to,
+		Metric: metric,
+		Series: []*entity.StatsSeries{},
+	}
+
+	// points arrive ordered by subject, then date
+	var current *entity.StatsSeries
+	for _, point := range points {
+		if current == nil || current.Subject != point.Subject {
+			current = &entity.StatsSeries{Subject: point.Subject}
+			history.Series = append(history.Series, current)
+		}
+
+		current.Points = append(current.Points, point)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestHistoryService_TakeSnapshot(t *testing.T) {
+	repo := new(MockSnapshotRepository)
+	now := time.Date(2025, time.May, 3, 23, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	repo.On("TakeSnapshot", mock.Anything, now.UTC()).Return(nil)
+
+	svc := NewHistoryService(repo)
+	svc.now = func() time.Time { return now }
+
+	assert.NoError(t, svc.TakeSnapshot(t.Context()))
+	repo.AssertExpectations(t)
+}
+
+func TestHistoryService_GetHistory(t *testing.T) {
+	from := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	day2 := from.AddDate(0, 0, 1)
+
+	t.Run("points are split into series per subject", func(t *testing.T) {
+		repo := new(MockSnapshotRepository)
+		repo.On("GetHistory", mock.Anything, entity.MetricOpenReviewsByUser, "", from, to).
+			Return([]*entity.StatsPoint{
+				{Date: from, Subject: "u1", Value: 2},
+				{Date: day2, Subject: "u1", Value: 3},
+				{Date: from, Subject: "u2", Value: 1},
+			}, nil)
+
+		svc := NewHistoryService(repo)
+
+		history, err := svc.GetHistory(t.Context(), entity.MetricOpenReviewsByUser, "", from, to)
+		assert.NoError(t, err)
+		assert.Len(t, history.Series, 2)
+		assert.Equal(t, "u1", history.Series[0].Subject)
+		assert.Len(t, history.Series[0].Points, 2)
+		assert.Equal(t, "u2", history.Series[1].Subject)
+	})
+
+	t.Run("no snapshots yields empty series", func(t *testing.T) {
+		repo := new(MockSnapshotRepository)
+		repo.On("GetHistory", mock.Anything, entity.MetricOpenPRs, "", from, to).
+			Return([]*entity.StatsPoint{}, nil)
+
+		svc := NewHistoryService(repo)
+
+		history, err := svc.GetHistory(t.Context(), entity.MetricOpenPRs, "", from, to)
+		assert.NoError(t, err)
+		assert.Empty(t, history.Series)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockSnapshotRepository)
+		repo.On("GetHistory", mock.Anything, entity.MetricMerges, "", from, to).
+			Return(nil, errors.New("db error"))
+
+		svc := NewHistoryService(repo)
+
+		_, err := svc.GetHistory(t.Context(), entity.MetricMerges, "", from, to)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const (
+	importTimeout = 10 * time.Second
+)
+
+// ImportService loads and dumps team rosters in bulk.
+type ImportService struct {
+	teamRepo postgres.TeamRepository
+	userRepo postgres.UserRepository
+}
+
+func NewImportService(t postgres.TeamRepository, u postgres.UserRepository) *ImportService {
+	return &ImportService{teamRepo: t, userRepo: u}
+}
+
+// Import computes what importing teams would change and, unless dryRun,
+// applies it in one transaction. Members already in the database but absent
+// from the import are kept.
+func (s *ImportService) Import(
+	ctx context.Context,
+	teams []entity.Team,
+	dryRun bool,
+) (*entity.ImportReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	report, err := s.diff(queryCtx, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DryRun = dryRun
+	if dryRun {
+		return report, nil
+	}
+
+	if err := s.teamRepo.ImportTeams(queryCtx, teams); err != nil {
+		return nil, err
+	}
+
+	report.Applied = true
+
+	return report, nil
+}
+
+// Export returns every team with its members, ordered by name.
+func (s *ImportService) Export(ctx context.Context) ([]entity.Team, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	names, err := s.teamRepo.ListTeamNames(queryCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]entity.Team, 0, len(names))
+	for _, name := range names {
+		team, err := s.teamRepo.GetTeam(queryCtx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, *team)
+	}
+
+	return teams, nil
+}
+
+//nolint:gocognit,cyclop // walks teams and members once
+func (s *ImportService) diff(ctx context.Context, teams []entity.Team) (*entity.ImportReport, error) {
+	report := &entity.ImportReport{
+		Creates: []entity.ImportChange{},
+		Updates: []entity.ImportChange{},
+		Moves:   []entity.ImportChange{},
+	}
+
+	for i := range teams {
+		team := &teams[i]
+
+		current, err := s.teamRepo.GetTeam(ctx, team.TeamName)
+		if err != nil && err.Error() != notFoundErr {
+			return nil, err
+		}
+
+		existing := map[string]entity.TeamMember{}
+		if current == nil {
+			report.Creates = append(report.Creates, entity.ImportChange{
+				Kind: entity.ImportTeam, TeamName: team.TeamName,
+			})
+		} else {
+			if fields := teamChanges(current, team); len(fields) > 0 {
+				report.Updates = append(report.Updates, entity.ImportChange{
+					Kind: entity.ImportTeam, TeamName: team.TeamName, Fields: fields,
+				})
+			}
+
+			for _, member := range current.Members {
+				existing[member.UserID] = member
+			}
+		}
+
+		for _, member := range team.Members {
+			change := entity.ImportChange{
+				Kind: entity.ImportUser, TeamName: team.TeamName, UserID: member.UserID,
+			}
+
+			if old, ok := existing[member.UserID]; ok {
+				if change.Fields = memberChanges(&old, &member); len(change.Fields) > 0 {
+					report.Updates = append(report.Updates, change)
+				} else {
+					report.Unchanged++
+				}
+
+				continue
+			}
+
+			user, err := s.userRepo.GetUser(ctx, member.UserID)
+			if err != nil {
+				// GetUser reports every failure as not found
+				report.Creates = append(report.Creates, change)
+				continue
+			}
+
+			change.FromTeam = user.TeamName
+			change.Fields = memberChanges(&entity.TeamMember{
+				MaxOpenReviews: user.MaxOpenReviews,
+				UserID:         user.UserID,
+				Username:       user.Username,
+				TimeZone:       user.TimeZone,
+				WorkStart:      user.WorkStart,
+				WorkEnd:        user.WorkEnd,
+				IsActive:       user.IsActive,
+			}, &member)
+			report.Moves = append(report.Moves, change)
+		}
+	}
+
+	return report, nil
+}
+
+// teamChanges lists settings the import changes; unset settings are kept.
+func teamChanges(current, imported *entity.Team) []string {
+	var fields []string
+
+	if imported.DefaultMaxOpenReviews != nil && !equalInt(current.DefaultMaxOpenReviews, imported.DefaultMaxOpenReviews) {
+		fields = append(fields, "default_max_open_reviews")
+	}
+
+	if imported.ReviewSLAMinutes != nil && !equalInt(current.ReviewSLAMinutes, imported.ReviewSLAMinutes) {
+		fields = append(fields, "review_sla_minutes")
+	}
+
+	if imported.LeadUserID != "" && current.LeadUserID != imported.LeadUserID {
+		fields = append(fields, "lead_user_id")
+	}
+
+	return fields
+}
+
+// memberChanges lists the member fields the import overwrites with a
+// different value; an existing member keeps the limit and working hours the
+// import leaves unset.
+func memberChanges(current, imported *entity.TeamMember) []string {
+	var fields []string
+
+	if current.Username != imported.Username {
+		fields = append(fields, "username")
+	}
+
+	if current.IsActive != imported.IsActive {
+		fields = append(fields, "is_active")
+	}
+
+	if imported.MaxOpenReviews != nil && !equalInt(current.MaxOpenReviews, imported.MaxOpenReviews) {
+		fields = append(fields, "max_open_reviews")
+	}
+
+	if imported.TimeZone != emptyString && current.TimeZone != imported.TimeZone {
+		fields = append(fields, "time_zone")
+	}
+
+	if changedString(current.WorkStart, imported.WorkStart) || changedString(current.WorkEnd, imported.WorkEnd) {
+		fields = append(fields, "working_hours")
+	}
+
+	return fields
+}
+
+// changedString reports whether a set imported value differs from the
+// current one.
+func changedString(current, imported string) bool {
+	return imported != emptyString && current != imported
+}
+
+func equalInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
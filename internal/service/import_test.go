@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func importFixture() (*MockTeamRepository, *MockUserRepository, []entity.Team) {
+	limit := 3
+	teamRepo := new(MockTeamRepository)
+	userRepo := new(MockUserRepository)
+
+	teamRepo.On("GetTeam", mock.Anything, "backend").Return(&entity.Team{
+		TeamName: "backend",
+		Members: []entity.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, TimeZone: "Europe/Moscow",
+				WorkStart: "09:00", WorkEnd: "18:00"},
+			{UserID: "u2", Username: "Bob", IsActive: true, TimeZone: "UTC"},
+		},
+	}, nil)
+	teamRepo.On("GetTeam", mock.Anything, "mobile").Return(nil, errors.New(notFoundErr))
+	userRepo.On("GetUser", mock.Anything, "u3").Return(&entity.User{
+		UserID: "u3", Username: "Carol", TeamName: "frontend", IsActive: true, TimeZone: "UTC",
+	}, nil)
+	userRepo.On("GetUser", mock.Anything, "u4").Return(nil, errors.New(notFoundErr))
+
+	teams := []entity.Team{
+		{
+			TeamName:         "backend",
+			ReviewSLAMinutes: &limit,
+			Members: []entity.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: false, MaxOpenReviews: &limit},
+				{UserID: "u3", Username: "Carol", IsActive: true},
+			},
+		},
+		{
+			TeamName: "mobile",
+			Members:  []entity.TeamMember{{UserID: "u4", Username: "Dan", IsActive: true}},
+		},
+	}
+
+	return teamRepo, userRepo, teams
+}
+
+func TestImportService_Import(t *testing.T) {
+	t.Run("dry run reports the diff without writing", func(t *testing.T) {
+		teamRepo, userRepo, teams := importFixture()
+		svc := NewImportService(teamRepo, userRepo)
+
+		report, err := svc.Import(t.Context(), teams, true)
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		assert.False(t, report.Applied)
+		assert.Equal(t, 1, report.Unchanged)
+		assert.Equal(t, []entity.ImportChange{
+			{Kind: entity.ImportTeam, TeamName: "mobile"},
+			{Kind: entity.ImportUser, TeamName: "mobile", UserID: "u4"},
+		}, report.Creates)
+		assert.Equal(t, []entity.ImportChange{
+			{Kind: entity.ImportTeam, TeamName: "backend", Fields: []string{"review_sla_minutes"}},
+			{Kind: entity.ImportUser, TeamName: "backend", UserID: "u2",
+				Fields: []string{"is_active", "max_open_reviews"}},
+		}, report.Updates)
+		assert.Equal(t, []entity.ImportChange{
+			{Kind: entity.ImportUser, TeamName: "backend", UserID: "u3", FromTeam: "frontend"},
+		}, report.Moves)
+		teamRepo.AssertNotCalled(t, "ImportTeams", mock.Anything, mock.Anything)
+	})
+
+	t.Run("given working hours are reported", func(t *testing.T) {
+		teamRepo, userRepo, teams := importFixture()
+		teams[0].Members[0].TimeZone = "Asia/Tokyo"
+		teams[0].Members[0].WorkEnd = "17:00"
+		svc := NewImportService(teamRepo, userRepo)
+
+		report, err := svc.Import(t.Context(), teams, true)
+		require.NoError(t, err)
+
+		assert.Contains(t, report.Updates, entity.ImportChange{
+			Kind: entity.ImportUser, TeamName: "backend", UserID: "u1",
+			Fields: []string{"time_zone", "working_hours"},
+		})
+	})
+
+	t.Run("apply writes in one call", func(t *testing.T) {
+		teamRepo, userRepo, teams := importFixture()
+		teamRepo.On("ImportTeams", mock.Anything, teams).Return(nil).Once()
+		svc := NewImportService(teamRepo, userRepo)
+
+		report, err := svc.Import(t.Context(), teams, false)
+		require.NoError(t, err)
+		assert.True(t, report.Applied)
+		teamRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed write is returned", func(t *testing.T) {
+		teamRepo, userRepo, teams := importFixture()
+		teamRepo.On("ImportTeams", mock.Anything, teams).Return(errors.New("deadlock"))
+		svc := NewImportService(teamRepo, userRepo)
+
+		_, err := svc.Import(t.Context(), teams, false)
+		assert.EqualError(t, err, "deadlock")
+	})
+}
+
+func TestImportService_Export(t *testing.T) {
+	teamRepo := new(MockTeamRepository)
+	teamRepo.On("ListTeamNames", mock.Anything).Return([]string{"a", "b"}, nil)
+	teamRepo.On("GetTeam", mock.Anything, "a").Return(&entity.Team{TeamName: "a"}, nil)
+	teamRepo.On("GetTeam", mock.Anything, "b").Return(&entity.Team{TeamName: "b"}, nil)
+
+	teams, err := NewImportService(teamRepo, new(MockUserRepository)).Export(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Team{{TeamName: "a"}, {TeamName: "b"}}, teams)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -60,13 +61,44 @@ func (m *MockPullRequestRepository) GetOpenPRsByReviewer(ctx context.Context, re
 	return pr, args.Error(1)
 }
 
-type MockUserRepository struct {
-	mock.Mock
+func (m *MockPullRequestRepository) GetRecentPairings(
+	ctx context.Context,
+	authorID string,
+	since time.Time,
+) (map[string]int, error) {
+	args := m.Called(ctx, authorID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	pairings, ok := args.Get(0).(map[string]int)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return pairings, args.Error(1)
 }
 
-func (m *MockUserRepository) MassDeactivateAndReassign(ctx context.Context, teamName string, userIDs []string) error {
-	args := m.Called(ctx, teamName, userIDs)
-	return args.Error(0)
+func (m *MockPullRequestRepository) ListPRsByRepository(
+	ctx context.Context,
+	repositoryName string,
+	status entity.PRStatus,
+) ([]*entity.PullRequestShort, error) {
+	args := m.Called(ctx, repositoryName, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	prs, ok := args.Get(0).([]*entity.PullRequestShort)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return prs, args.Error(1)
+}
+
+type MockUserRepository struct {
+	mock.Mock
 }
 
 func (m *MockUserRepository) GetUser(ctx context.Context, userID string) (*entity.User, error) {
@@ -120,6 +152,35 @@ func (m *MockUserRepository) GetPRsForReviewer(ctx context.Context, userID strin
 	return prs, args.Error(1)
 }
 
+func (m *MockUserRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	args := m.Called(ctx, userID, maxOpenReviews)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetWorkingHours(ctx context.Context, userID, timeZone, workStart, workEnd string) error {
+	args := m.Called(ctx, userID, timeZone, workStart, workEnd)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CanTakeReview(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) GetReviewCapacity(ctx context.Context, userID string) (*entity.ReviewCapacity, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	capacity, ok := args.Get(0).(*entity.ReviewCapacity)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return capacity, args.Error(1)
+}
+
 type MockTeamRepository struct {
 	mock.Mock
 }
@@ -147,3 +208,232 @@ func (m *MockTeamRepository) TeamExists(ctx context.Context, teamName string) (b
 	args := m.Called(ctx, teamName)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockTeamRepository) SetReviewSLA(ctx context.Context, teamName string, slaMinutes *int, leadUserID string) error {
+	args := m.Called(ctx, teamName, slaMinutes, leadUserID)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTeamRepository) ImportTeams(ctx context.Context, teams []entity.Team) error {
+	args := m.Called(ctx, teams)
+	return args.Error(0)
+}
+
+type MockSLARepository struct {
+	mock.Mock
+}
+
+func (m *MockSLARepository) GetOverdueReviews(ctx context.Context, onlyUnflagged bool) ([]*entity.OverdueReview, error) {
+	args := m.Called(ctx, onlyUnflagged)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	reviews, ok := args.Get(0).([]*entity.OverdueReview)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return reviews, args.Error(1)
+}
+
+func (m *MockSLARepository) MarkOverdueFlagged(ctx context.Context, prID, reviewerID string) error {
+	args := m.Called(ctx, prID, reviewerID)
+	return args.Error(0)
+}
+
+type MockSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockSnapshotRepository) TakeSnapshot(ctx context.Context, day time.Time) error {
+	args := m.Called(ctx, day)
+	return args.Error(0)
+}
+
+func (m *MockSnapshotRepository) GetHistory(
+	ctx context.Context,
+	metric entity.StatsMetric,
+	subject string,
+	from, to time.Time,
+) ([]*entity.StatsPoint, error) {
+	args := m.Called(ctx, metric, subject, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	points, ok := args.Get(0).([]*entity.StatsPoint)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return points, args.Error(1)
+}
+
+type MockRepositoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryRepository) AddRepository(ctx context.Context, repository *entity.Repository) error {
+	args := m.Called(ctx, repository)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryRepository) GetRepository(ctx context.Context, name string) (*entity.Repository, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	repository, ok := args.Get(0).(*entity.Repository)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return repository, args.Error(1)
+}
+
+func (m *MockRepositoryRepository) RepositoryExists(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) AddUnavailability(ctx context.Context, window *entity.Unavailability) error {
+	args := m.Called(ctx, window)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) ListUnavailability(
+	ctx context.Context,
+	userID string,
+) ([]*entity.Unavailability, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	windows, ok := args.Get(0).([]*entity.Unavailability)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return windows, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) DeleteUnavailability(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) GetStartedHandovers(ctx context.Context) ([]*entity.Unavailability, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	windows, ok := args.Get(0).([]*entity.Unavailability)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return windows, args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) MarkHandedOver(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockConflictRepository struct {
+	mock.Mock
+}
+
+func (m *MockConflictRepository) AddConflict(ctx context.Context, conflict *entity.ReviewerConflict) error {
+	args := m.Called(ctx, conflict)
+	return args.Error(0)
+}
+
+func (m *MockConflictRepository) DeleteConflict(ctx context.Context, userID, otherUserID string) error {
+	args := m.Called(ctx, userID, otherUserID)
+	return args.Error(0)
+}
+
+func (m *MockConflictRepository) ListConflicts(
+	ctx context.Context,
+	userID string,
+) ([]*entity.ReviewerConflict, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	conflicts, ok := args.Get(0).([]*entity.ReviewerConflict)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return conflicts, args.Error(1)
+}
+
+func (m *MockConflictRepository) GetConflictingUsers(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	userIDs, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return userIDs, args.Error(1)
+}
+
+type MockDecisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockDecisionRepository) AddDecision(ctx context.Context, decision *entity.AssignmentDecision) error {
+	args := m.Called(ctx, decision)
+	return args.Error(0)
+}
+
+func (m *MockDecisionRepository) ListDecisions(
+	ctx context.Context,
+	prID string,
+) ([]*entity.AssignmentDecision, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	decisions, ok := args.Get(0).([]*entity.AssignmentDecision)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return decisions, args.Error(1)
+}
+
+// MockTransactor runs fn directly and counts the transactions, returning
+// the error of fn like a rolled back transaction would.
+type MockTransactor struct {
+	Calls int
+}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
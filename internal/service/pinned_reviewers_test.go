@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestPRService_CreatePRPinnedReviewers(t *testing.T) {
+	team := &entity.Team{
+		TeamName: "team1",
+		Members: []entity.TeamMember{
+			{UserID: "author", IsActive: true},
+			{UserID: "req", IsActive: true},
+			{UserID: "pair", IsActive: true},
+			{UserID: "away", IsActive: false},
+			{UserID: "other", IsActive: true},
+		},
+	}
+
+	newService := func() (*PRService, *MockPullRequestRepository, *MockUserRepository) {
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+		teamRepo := new(MockTeamRepository)
+
+		prRepo.On("PRExists", mock.Anything, "pr-1").Return(false, nil)
+		userRepo.On("GetUser", mock.Anything, "author").
+			Return(&entity.User{UserID: "author", TeamName: "team1", IsActive: true}, nil)
+		teamRepo.On("GetTeam", mock.Anything, "team1").Return(team, nil)
+
+		return NewPRService(prRepo, userRepo, teamRepo), prRepo, userRepo
+	}
+
+	params := func(required, excluded []string) entity.PRCreateParams {
+		return entity.PRCreateParams{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Pinned",
+			AuthorID:          "author",
+			RequiredReviewers: required,
+			ExcludedReviewers: excluded,
+		}
+	}
+
+	t.Run("required reviewer is assigned and selection fills the rest", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "req", "pair"}).
+			Return([]*entity.User{{UserID: "other"}}, nil)
+		prRepo.On("CreatePR", mock.Anything, mock.Anything, []string{"req", "other"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AssignedReviewers: []string{"req", "other"},
+		}, nil)
+
+		pr, _, err := s.CreatePR(t.Context(), params([]string{"req"}, []string{"pair"}))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"req", "other"}, pr.AssignedReviewers)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("two required reviewers leave no slot to fill", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+		decisionRepo := new(MockDecisionRepository)
+		WithDecisions(decisionRepo)(s)
+
+		prRepo.On("CreatePR", mock.Anything, mock.Anything, []string{"req", "pair"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{PullRequestID: "pr-1"}, nil)
+
+		_, _, err := s.CreatePR(t.Context(), params([]string{"req", "pair"}, nil))
+		require.NoError(t, err)
+		prRepo.AssertExpectations(t)
+		userRepo.AssertNotCalled(t, "GetActiveUsersByTeam", mock.Anything, mock.Anything, mock.Anything)
+		decisionRepo.AssertNotCalled(t, "AddDecision", mock.Anything, mock.Anything)
+	})
+
+	invalid := []struct {
+		name     string
+		required []string
+		excluded []string
+	}{
+		{name: "required reviewer outside the team", required: []string{"stranger"}},
+		{name: "inactive required reviewer", required: []string{"away"}},
+		{name: "author as required reviewer", required: []string{"author"}},
+		{name: "required and excluded at once", required: []string{"req"}, excluded: []string{"req"}},
+		{name: "excluded reviewer outside the team", excluded: []string{"stranger"}},
+		{name: "too many required reviewers", required: []string{"req", "pair", "other"}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			s, prRepo, _ := newService()
+
+			_, _, err := s.CreatePR(t.Context(), params(tt.required, tt.excluded))
+			assert.ErrorIs(t, err, entity.ErrInvalidReviewer)
+			prRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -242,7 +242,11 @@ func TestPRService_CreatePR(t *testing.T) {
 			service := NewPRService(prRepo, userRepo, teamRepo)
 			ctx := t.Context()
 
-			pr, msg, err := service.CreatePR(ctx, tt.prID, tt.prName, tt.authorID)
+			pr, msg, err := service.CreatePR(ctx, entity.PRCreateParams{
+				PullRequestID:   tt.prID,
+				PullRequestName: tt.prName,
+				AuthorID:        tt.authorID,
+			})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -413,41 +417,20 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		expectedNewID bool
 	}{
 		{
-			name:          "assign when no existing reviewers (old id empty)",
+			name:          "empty old reviewer is not assigned",
 			prID:          "pr1",
 			oldReviewerID: "",
 			setupMocks: func(prRepo *MockPullRequestRepository, userRepo *MockUserRepository) {
-				now := time.Now()
 				prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
 					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR",
-					AuthorID:          "user1",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{},
-					CreatedAt:         &now,
-				}, nil).Once()
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "user1",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil)
-				userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"user1"}).Return([]*entity.User{
-					{UserID: "user2", Username: "user2", TeamName: "team1", IsActive: true},
-				}, nil)
-				prRepo.On("UpdateReviewers", mock.Anything, "pr1", mock.Anything).Return(nil)
-				prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR",
 					AuthorID:          "user1",
 					Status:            entity.OPEN,
 					AssignedReviewers: []string{"user2"},
-					CreatedAt:         &now,
-				}, nil).Once()
+				}, nil)
 			},
-			expectedError: "",
-			expectedPR:    true,
-			expectedNewID: true,
+			expectedError: "NOT_ASSIGNED",
+			expectedPR:    false,
+			expectedNewID: false,
 		},
 		{
 			name:          "successful reassignment",
@@ -695,3 +678,69 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 		})
 	}
 }
+
+func TestPRService_CreatePRInRepository(t *testing.T) {
+	t.Run("uses repository key and default team", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+		teamRepo := new(MockTeamRepository)
+		repoRepo := new(MockRepositoryRepository)
+
+		repoRepo.On("GetRepository", mock.Anything, "backend").Return(&entity.Repository{
+			RepositoryName: "backend",
+			VCS:            entity.DefaultVCS,
+			DefaultTeam:    "platform",
+		}, nil)
+		prRepo.On("PRExists", mock.Anything, "backend#7").Return(false, nil)
+		userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
+			UserID:   "user1",
+			TeamName: "team1",
+			IsActive: true,
+		}, nil)
+		teamRepo.On("GetTeam", mock.Anything, "platform").Return(&entity.Team{TeamName: "platform"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "platform", []string{"user1"}).
+			Return([]*entity.User{{UserID: "user5", TeamName: "platform", IsActive: true}}, nil)
+		prRepo.On("CreatePR", mock.Anything, mock.MatchedBy(func(pr *entity.PullRequest) bool {
+			return pr.PullRequestID == "backend#7" && pr.RepositoryName == "backend" && pr.Number == 7
+		}), []string{"user5"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "backend#7").Return(&entity.PullRequest{
+			PullRequestID:     "backend#7",
+			RepositoryName:    "backend",
+			Number:            7,
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"user5"},
+		}, nil)
+
+		svc := NewPRService(prRepo, userRepo, teamRepo, WithRepositories(repoRepo))
+
+		pr, _, err := svc.CreatePR(t.Context(), entity.PRCreateParams{
+			PullRequestName: "Test PR",
+			AuthorID:        "user1",
+			RepositoryName:  "backend",
+			Number:          7,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "backend#7", pr.PullRequestID)
+
+		prRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		teamRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown repository returns NOT_FOUND", func(t *testing.T) {
+		repoRepo := new(MockRepositoryRepository)
+		repoRepo.On("GetRepository", mock.Anything, "ghost").Return(nil, errors.New("NOT_FOUND"))
+
+		svc := NewPRService(new(MockPullRequestRepository), new(MockUserRepository),
+			new(MockTeamRepository), WithRepositories(repoRepo))
+
+		pr, _, err := svc.CreatePR(t.Context(), entity.PRCreateParams{
+			PullRequestName: "Test PR",
+			AuthorID:        "user1",
+			RepositoryName:  "ghost",
+			Number:          1,
+		})
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, pr)
+	})
+}
@@ -3,13 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
-	"strings"
+	"slices"
 	"time"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	//nolint:revive // necessary import
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
 )
 
 const (
@@ -17,32 +19,142 @@ const (
 	emptyString    = ""
 	notFoundErr    = "NOT_FOUND"
 	zeroLength     = 0
+	maxReviewers   = 2
 )
 
 type PRService struct {
 	repo     postgres.PullRequestRepository
 	userRepo postgres.UserRepository
 	teamRepo postgres.TeamRepository
+	repoRepo postgres.RepositoryRepository
+	tx       postgres.Transactor
+
+	conflictRepo postgres.ConflictRepository
+	decisionRepo postgres.DecisionRepository
+
+	now                func() time.Time
+	nextSeed           func() int64
+	workingHoursSLA    time.Duration
+	pairRotationWindow time.Duration
+	preferWorkingHours bool
+}
+
+type PROption func(s *PRService)
+
+// WithRepositories enables creating PRs inside registered repositories.
+func WithRepositories(r postgres.RepositoryRepository) PROption {
+	return func(s *PRService) {
+		s.repoRepo = r
+	}
 }
 
 //nolint:revive // func
-func NewPRService(r postgres.PullRequestRepository, u postgres.UserRepository, t postgres.TeamRepository) *PRService {
-	return &PRService{
+func NewPRService(
+	r postgres.PullRequestRepository,
+	u postgres.UserRepository,
+	t postgres.TeamRepository,
+	options ...PROption,
+) *PRService {
+	s := &PRService{
 		repo:     r,
 		userRepo: u,
 		teamRepo: t,
+		tx:       noTx{},
+		now:      time.Now,
+		nextSeed: rand.Int63,
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// validatePinnedReviewers checks that required and excluded reviewers belong
+// to the reviewing team, and that required ones are active, are not the
+// author, do not conflict with the author and fit into the reviewer limit.
+// Required reviewers skip the capacity and availability filters.
+func validatePinnedReviewers(team *entity.Team, params entity.PRCreateParams, conflicting []string) error {
+	if len(params.RequiredReviewers) > maxReviewers {
+		return fmt.Errorf("%w: at most %d required reviewers", entity.ErrInvalidReviewer, maxReviewers)
+	}
+
+	members := make(map[string]entity.TeamMember, len(team.Members))
+	for _, member := range team.Members {
+		members[member.UserID] = member
+	}
+
+	for _, id := range params.RequiredReviewers {
+		member, ok := members[id]
+
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: %s is not a member of team %s", entity.ErrInvalidReviewer, id, team.TeamName)
+		case !member.IsActive:
+			return fmt.Errorf("%w: %s is not active", entity.ErrInvalidReviewer, id)
+		case id == params.AuthorID:
+			return fmt.Errorf("%w: author cannot review their own PR", entity.ErrInvalidReviewer)
+		case slices.Contains(params.ExcludedReviewers, id):
+			return fmt.Errorf("%w: %s is both required and excluded", entity.ErrInvalidReviewer, id)
+		case slices.Contains(conflicting, id):
+			return fmt.Errorf("%w: %s conflicts with the author", entity.ErrInvalidReviewer, id)
+		}
+	}
+
+	for _, id := range params.ExcludedReviewers {
+		if _, ok := members[id]; !ok {
+			return fmt.Errorf("%w: %s is not a member of team %s", entity.ErrInvalidReviewer, id, team.TeamName)
+		}
+	}
+
+	return nil
+}
+
+// getRepository resolves the repository a PR is created in; nil means the
+// legacy flat pull_request_id form.
+func (s *PRService) getRepository(
+	ctx context.Context,
+	name string,
+) (*entity.Repository, error) {
+	if name == emptyString {
+		return nil, nil
 	}
+
+	if s.repoRepo == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	repository, err := s.repoRepo.GetRepository(ctx, name)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	return repository, nil
 }
 
-//nolint:revive,cyclop // Complex business logic for PR creation
+//nolint:revive,cyclop,funlen // Complex business logic for PR creation
 func (s *PRService) CreatePR(
 	ctx context.Context,
-	prID, prName, authorID string,
+	params entity.PRCreateParams,
 ) (*entity.PullRequest, string, error) {
+	ctx, span := tracing.Start(ctx, "PRService.CreatePR")
+	defer span.End()
+
 	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
 
 	defer cancel()
 
+	prID, prName, authorID := params.PullRequestID, params.PullRequestName, params.AuthorID
+
+	repository, err := s.getRepository(queryCtx, params.RepositoryName)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	if repository != nil {
+		prID = entity.PRKey(repository.RepositoryName, params.Number)
+	}
+
 	exists, err := s.repo.PRExists(queryCtx, prID)
 	if err != nil {
 		return nil, emptyString, err
@@ -57,32 +169,54 @@ func (s *PRService) CreatePR(
 		return nil, emptyString, entity.ErrNotFound
 	}
 
-	_, err = s.teamRepo.GetTeam(queryCtx, author.TeamName)
+	// reviewers come from the repository's default team when it has one
+	reviewTeam := author.TeamName
+	if repository != nil && repository.DefaultTeam != emptyString {
+		reviewTeam = repository.DefaultTeam
+	}
+
+	team, err := s.teamRepo.GetTeam(queryCtx, reviewTeam)
 	if err != nil {
 		return nil, emptyString, entity.ErrNotFound
 	}
 
-	candidates, err := s.userRepo.GetActiveUsersByTeam(queryCtx, author.TeamName, []string{authorID})
+	conflicting, err := s.conflictsOf(queryCtx, authorID)
 	if err != nil {
 		return nil, emptyString, err
 	}
 
-	reviewerIDs := []string{}
+	if err := validatePinnedReviewers(team, params, conflicting); err != nil {
+		return nil, emptyString, err
+	}
+
+	reviewerIDs := append([]string{}, params.RequiredReviewers...)
 
-	if len(candidates) > 0 {
-		shuffled := make([]*entity.User, len(candidates))
-		copy(shuffled, candidates)
-		rand.Shuffle(len(shuffled), func(i, j int) {
-			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-		})
+	// stays nil when the required reviewers fill the PR and nothing is picked
+	var decision *entity.AssignmentDecision
 
-		count := 2
-		if len(shuffled) < count {
-			count = len(shuffled)
+	if remaining := maxReviewers - len(reviewerIDs); remaining > zeroLength {
+		exclude := make([]string, 0, 1+len(params.RequiredReviewers)+len(params.ExcludedReviewers)+len(conflicting))
+		exclude = append(exclude, authorID)
+		exclude = append(exclude, params.RequiredReviewers...)
+		exclude = append(exclude, params.ExcludedReviewers...)
+		exclude = append(exclude, conflicting...)
+
+		candidates, err := s.userRepo.GetActiveUsersByTeam(queryCtx, reviewTeam, exclude)
+		if err != nil {
+			return nil, emptyString, err
 		}
 
-		for i := range count {
-			reviewerIDs = append(reviewerIDs, shuffled[i].UserID)
+		pairings, err := s.recentPairings(queryCtx, authorID)
+		if err != nil {
+			return nil, emptyString, err
+		}
+
+		var picked []*entity.User
+
+		picked, decision = s.pickReviewers(operationCreate, candidates, remaining, pairings)
+
+		for _, reviewer := range picked {
+			reviewerIDs = append(reviewerIDs, reviewer.UserID)
 		}
 	}
 
@@ -96,7 +230,20 @@ func (s *PRService) CreatePR(
 		CreatedAt:         &now,
 	}
 
-	err = s.repo.CreatePR(queryCtx, pr, reviewerIDs)
+	if repository != nil {
+		pr.RepositoryName = repository.RepositoryName
+		pr.Number = params.Number
+	}
+
+	err = s.withinTx(queryCtx, false, func(ctx context.Context) error {
+		if err := s.repo.CreatePR(ctx, pr, reviewerIDs); err != nil {
+			return err
+		}
+
+		observeAssignment(ctx, decision)
+
+		return s.recordDecision(ctx, prID, decision)
+	})
 	if err != nil {
 		if errors.Is(err, entity.ErrPRExists) {
 			return nil, emptyString, err
@@ -115,6 +262,9 @@ func (s *PRService) CreatePR(
 
 //nolint:revive // func
 func (s *PRService) MergePR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PRService.MergePR")
+	defer span.End()
+
 	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
 	defer cancel()
 
@@ -144,6 +294,9 @@ func (s *PRService) ReassignReviewer(
 	ctx context.Context,
 	prID, oldReviewerID string,
 ) (*entity.PullRequest, string, error) {
+	ctx, span := tracing.Start(ctx, "PRService.ReassignReviewer")
+	defer span.End()
+
 	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
 
 	defer cancel()
@@ -157,87 +310,7 @@ func (s *PRService) ReassignReviewer(
 		return nil, emptyString, entity.ErrPRMerged
 	}
 
-	// If oldReviewerID is empty, interpret as "assign a new reviewer" (append)
-	if oldReviewerID == emptyString {
-		// use PR author team to find candidates (same as create PR flow)
-		author, err := s.userRepo.GetUser(queryCtx, pr.AuthorID)
-		if err != nil {
-			return nil, emptyString, entity.ErrNotFound
-		}
-
-		// exclude author and any already assigned reviewers
-		exclude := make([]string, 0, len(pr.AssignedReviewers)+1)
-		exclude = append(exclude, pr.AuthorID)
-		exclude = append(exclude, pr.AssignedReviewers...)
-
-		candidates, err := s.userRepo.GetActiveUsersByTeam(queryCtx, author.TeamName, exclude)
-		if err != nil {
-			return nil, emptyString, err
-		}
-		// If PR currently has no reviewers, allow assigning up to 2 candidates (0..2)
-		if len(pr.AssignedReviewers) == 0 {
-			// if no candidates found, it's acceptable — return current PR without error
-			if len(candidates) == zeroLength {
-				return pr, emptyString, nil
-			}
-
-			// shuffle candidates and pick up to 2
-			shuffled := make([]*entity.User, len(candidates))
-			copy(shuffled, candidates)
-			rand.Shuffle(len(shuffled), func(i, j int) {
-				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-			})
-
-			pick := 2
-			if len(shuffled) < pick {
-				pick = len(shuffled)
-			}
-
-			selected := make([]string, 0, pick)
-			for i := range pick {
-				selected = append(selected, shuffled[i].UserID)
-			}
-
-			err = s.repo.UpdateReviewers(queryCtx, prID, selected)
-			if err != nil {
-				return nil, emptyString, err
-			}
-
-			updatedPR, error1 := s.repo.GetPR(queryCtx, prID)
-			if error1 != nil {
-				return nil, emptyString, err
-			}
-
-			// return comma-separated list of assigned IDs (may be 1 or 2)
-			return updatedPR, strings.Join(selected, ","), nil
-		}
-
-		// otherwise (existing reviewers present) pick a single reviewer to append
-		if len(candidates) == zeroLength {
-			return nil, emptyString, entity.ErrNoCandidate
-		}
-
-		//nolint:gosec // math/rand is sufficient for selecting a random reviewer
-		newReviewer := candidates[rand.Intn(len(candidates))]
-
-		newReviewers := make([]string, 0, len(pr.AssignedReviewers)+1)
-		newReviewers = append(newReviewers, pr.AssignedReviewers...)
-		newReviewers = append(newReviewers, newReviewer.UserID)
-
-		err = s.repo.UpdateReviewers(queryCtx, prID, newReviewers)
-		if err != nil {
-			return nil, emptyString, err
-		}
-
-		updatedPR, err := s.repo.GetPR(queryCtx, prID)
-		if err != nil {
-			return nil, emptyString, err
-		}
-
-		return updatedPR, newReviewer.UserID, nil
-	}
-
-	// Otherwise perform replacement of the specified old reviewer
+	// An empty oldReviewerID is never assigned; adding goes through AddReviewer.
 	found := false
 
 	for _, reviewerID := range pr.AssignedReviewers {
@@ -256,19 +329,30 @@ func (s *PRService) ReassignReviewer(
 		return nil, emptyString, entity.ErrNotFound
 	}
 
-	exclude := []string{pr.AuthorID, oldReviewerID}
+	conflicting, err := s.conflictsOf(queryCtx, pr.AuthorID)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	exclude := append([]string{pr.AuthorID, oldReviewerID}, conflicting...)
 
 	candidates, err := s.userRepo.GetActiveUsersByTeam(queryCtx, oldReviewer.TeamName, exclude)
 	if err != nil {
 		return nil, emptyString, err
 	}
 
-	if len(candidates) == zeroLength {
+	pairings, err := s.recentPairings(queryCtx, pr.AuthorID)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	picked, decision := s.pickReviewers(operationReassign, candidates, 1, pairings)
+	if len(picked) == zeroLength {
+		observeAssignment(queryCtx, decision)
 		return nil, emptyString, entity.ErrNoCandidate
 	}
 
-	//nolint:gosec // math/rand is sufficient for selecting a random reviewer
-	newReviewer := candidates[rand.Intn(len(candidates))]
+	newReviewer := picked[0]
 
 	newReviewers := make([]string, len(pr.AssignedReviewers))
 	copy(newReviewers, pr.AssignedReviewers)
@@ -280,7 +364,15 @@ func (s *PRService) ReassignReviewer(
 		}
 	}
 
-	err = s.repo.UpdateReviewers(queryCtx, prID, newReviewers)
+	err = s.withinTx(queryCtx, false, func(ctx context.Context) error {
+		if err := s.repo.UpdateReviewers(ctx, prID, newReviewers); err != nil {
+			return err
+		}
+
+		observeAssignment(ctx, decision)
+
+		return s.recordDecision(ctx, prID, decision)
+	})
 	if err != nil {
 		return nil, emptyString, err
 	}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+// WithTransactor makes multi-step changes such as mass deactivation run in
+// one database transaction.
+func WithTransactor(tx postgres.Transactor) PROption {
+	return func(s *PRService) {
+		s.tx = tx
+	}
+}
+
+// noTx runs fn directly; it stands in until a transactor is configured, so
+// without one a dry run is not rolled back.
+type noTx struct{}
+
+func (noTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// errDryRun rolls back the transaction of a dry run once fn has succeeded.
+var errDryRun = errors.New("dry run")
+
+// withinTx runs fn in one transaction. A dry run executes the same queries
+// and rolls them back, so fn can record what would change. Assignment
+// outcomes observed by fn reach the metrics only when the transaction
+// commits; a nested call hands them to the enclosing one.
+func (s *PRService) withinTx(
+	ctx context.Context,
+	dryRun bool,
+	fn func(ctx context.Context) error,
+) error {
+	parent, _ := ctx.Value(pendingOutcomesKey{}).(*pendingOutcomes)
+	pending := &pendingOutcomes{}
+
+	err := s.tx.WithinTx(context.WithValue(ctx, pendingOutcomesKey{}, pending), func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, decision := range pending.decisions {
+		if parent != nil {
+			parent.decisions = append(parent.decisions, decision)
+			continue
+		}
+
+		metrics.ObserveAssignment(decision.Operation, len(decision.Picked))
+	}
+
+	return nil
+}
+
+// PlanReassign runs ReassignReviewer in a rolled back transaction and returns
+// the PR as it would look afterwards, the planned reviewer and the change.
+func (s *PRService) PlanReassign(
+	ctx context.Context,
+	prID, oldReviewerID string,
+) (*entity.PullRequest, string, *entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "PRService.PlanReassign")
+	defer span.End()
+
+	var (
+		pr         *entity.PullRequest
+		replacedBy string
+	)
+
+	report := entity.NewReassignmentReport()
+	report.DryRun = true
+
+	err := s.withinTx(ctx, true, func(ctx context.Context) error {
+		before, err := s.repo.GetPR(ctx, prID)
+		if err != nil {
+			return entity.ErrNotFound
+		}
+
+		pr, replacedBy, err = s.ReassignReviewer(ctx, prID, oldReviewerID)
+		if err != nil {
+			return err
+		}
+
+		report.Add(entity.DiffReviewers(prID, before.AssignedReviewers, pr.AssignedReviewers))
+
+		return nil
+	})
+	if err != nil {
+		return nil, emptyString, nil, err
+	}
+
+	return pr, replacedBy, report, nil
+}
+
+// reassignAway takes removedIDs off every open PR they review. Each removed
+// reviewer is replaced, in place, by an eligible member of their own team who
+// is neither the author, in conflict with the author, already on the PR nor
+// being removed. PRs without a
+// replacement keep fewer reviewers and are reported as understaffed. Callers
+// run it inside a transaction.
+func (s *PRService) reassignAway(
+	ctx context.Context,
+	removedIDs []string,
+	report *entity.ReassignmentReport,
+) error {
+	removed := make(map[string]bool, len(removedIDs))
+	for _, id := range removedIDs {
+		removed[id] = true
+	}
+
+	prIDs, err := s.openPRsReviewedBy(ctx, removedIDs)
+	if err != nil {
+		return err
+	}
+
+	teams := make(map[string]string)
+	teamOf := func(userID string) (string, error) {
+		if team, ok := teams[userID]; ok {
+			return team, nil
+		}
+
+		user, err := s.userRepo.GetUser(ctx, userID)
+		if err != nil {
+			return emptyString, entity.ErrNotFound
+		}
+
+		teams[userID] = user.TeamName
+
+		return user.TeamName, nil
+	}
+
+	for _, prID := range prIDs {
+		pr, err := s.repo.GetPR(ctx, prID)
+		if err != nil {
+			return entity.ErrNotFound
+		}
+
+		var decisions []*entity.AssignmentDecision
+
+		change := &entity.PRReassignment{
+			PullRequestID: prID,
+			Removed:       []string{},
+			Added:         []string{},
+			Reviewers:     []string{},
+		}
+
+		conflicting, err := s.conflictsOf(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		exclude := make([]string, 0, len(pr.AssignedReviewers)+len(removedIDs)+len(conflicting)+1)
+		exclude = append(exclude, pr.AuthorID)
+		exclude = append(exclude, pr.AssignedReviewers...)
+		exclude = append(exclude, removedIDs...)
+		exclude = append(exclude, conflicting...)
+
+		pairings, err := s.recentPairings(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			if !removed[reviewerID] {
+				change.Reviewers = append(change.Reviewers, reviewerID)
+				continue
+			}
+
+			change.Removed = append(change.Removed, reviewerID)
+
+			team, err := teamOf(reviewerID)
+			if err != nil {
+				return err
+			}
+
+			candidates, err := s.userRepo.GetActiveUsersByTeam(ctx, team, exclude)
+			if err != nil {
+				return err
+			}
+
+			picked, decision := s.pickReviewers(operationReassign, candidates, 1, pairings)
+			observeAssignment(ctx, decision)
+
+			if len(picked) == zeroLength {
+				continue
+			}
+
+			decisions = append(decisions, decision)
+
+			change.Added = append(change.Added, picked[0].UserID)
+			change.Reviewers = append(change.Reviewers, picked[0].UserID)
+			exclude = append(exclude, picked[0].UserID)
+		}
+
+		change.Understaffed = len(change.Reviewers) < len(pr.AssignedReviewers)
+
+		if err := s.repo.UpdateReviewers(ctx, prID, change.Reviewers); err != nil {
+			return err
+		}
+
+		for _, decision := range decisions {
+			if err := s.recordDecision(ctx, prID, decision); err != nil {
+				return err
+			}
+		}
+
+		report.Add(change)
+	}
+
+	return nil
+}
+
+// openPRsReviewedBy returns the sorted, distinct open PRs reviewed by any of
+// userIDs.
+func (s *PRService) openPRsReviewedBy(ctx context.Context, userIDs []string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	var prIDs []string
+
+	for _, userID := range userIDs {
+		ids, err := s.repo.GetOpenPRsByReviewer(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				prIDs = append(prIDs, id)
+			}
+		}
+	}
+
+	sort.Strings(prIDs)
+
+	return prIDs, nil
+}
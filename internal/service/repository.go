@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const (
+	repositoryQueryTimeout = 300 * time.Millisecond
+)
+
+type RepositoryService struct {
+	repo     postgres.RepositoryRepository
+	prRepo   postgres.PullRequestRepository
+	teamRepo postgres.TeamRepository
+}
+
+func NewRepositoryService(repo postgres.RepositoryRepository,
+	prRepo postgres.PullRequestRepository,
+	teamRepo postgres.TeamRepository) *RepositoryService {
+	return &RepositoryService{
+		repo:     repo,
+		prRepo:   prRepo,
+		teamRepo: teamRepo,
+	}
+}
+
+//nolint:revive // func
+func (s *RepositoryService) AddRepository(
+	ctx context.Context,
+	repository *entity.Repository,
+) (*entity.Repository, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, repositoryQueryTimeout)
+	defer cancel()
+
+	exists, err := s.repo.RepositoryExists(queryCtx, repository.RepositoryName)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, entity.ErrRepositoryExists
+	}
+
+	if repository.DefaultTeam != "" {
+		teamExists, err := s.teamRepo.TeamExists(queryCtx, repository.DefaultTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		if !teamExists {
+			return nil, entity.ErrNotFound
+		}
+	}
+
+	if repository.VCS == "" {
+		repository.VCS = entity.DefaultVCS
+	}
+
+	err = s.repo.AddRepository(queryCtx, repository)
+	if err != nil {
+		if err.Error() == string(entity.CodeRepositoryExists) {
+			return nil, entity.ErrRepositoryExists
+		}
+
+		return nil, err
+	}
+
+	return s.repo.GetRepository(queryCtx, repository.RepositoryName)
+}
+
+//nolint:revive // func
+func (s *RepositoryService) GetRepository(ctx context.Context, name string) (*entity.Repository, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, repositoryQueryTimeout)
+	defer cancel()
+
+	repository, err := s.repo.GetRepository(queryCtx, name)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	return repository, nil
+}
+
+func (s *RepositoryService) ListPullRequests(
+	ctx context.Context,
+	name string,
+	status entity.PRStatus,
+) ([]*entity.PullRequestShort, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, repositoryQueryTimeout)
+	defer cancel()
+
+	exists, err := s.repo.RepositoryExists(queryCtx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, entity.ErrNotFound
+	}
+
+	return s.prRepo.ListPRsByRepository(queryCtx, name, status)
+}
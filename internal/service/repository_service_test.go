@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestRepositoryService_AddRepository(t *testing.T) {
+	tests := []struct {
+		repository    *entity.Repository
+		setupMocks    func(*MockRepositoryRepository, *MockTeamRepository)
+		name          string
+		expectedError string
+		expectedVCS   string
+	}{
+		{
+			name:       "successful creation with default vcs",
+			repository: &entity.Repository{RepositoryName: "backend", DefaultTeam: "team1"},
+			setupMocks: func(repoRepo *MockRepositoryRepository, teamRepo *MockTeamRepository) {
+				repoRepo.On("RepositoryExists", mock.Anything, "backend").Return(false, nil)
+				teamRepo.On("TeamExists", mock.Anything, "team1").Return(true, nil)
+				repoRepo.On("AddRepository", mock.Anything, mock.MatchedBy(func(r *entity.Repository) bool {
+					return r.VCS == entity.DefaultVCS
+				})).Return(nil)
+				repoRepo.On("GetRepository", mock.Anything, "backend").Return(&entity.Repository{
+					RepositoryName: "backend",
+					VCS:            entity.DefaultVCS,
+					DefaultTeam:    "team1",
+				}, nil)
+			},
+			expectedVCS: entity.DefaultVCS,
+		},
+		{
+			name:       "repository already exists",
+			repository: &entity.Repository{RepositoryName: "backend"},
+			setupMocks: func(repoRepo *MockRepositoryRepository, _ *MockTeamRepository) {
+				repoRepo.On("RepositoryExists", mock.Anything, "backend").Return(true, nil)
+			},
+			expectedError: "REPOSITORY_EXISTS",
+		},
+		{
+			name:       "default team not found",
+			repository: &entity.Repository{RepositoryName: "backend", DefaultTeam: "ghost"},
+			setupMocks: func(repoRepo *MockRepositoryRepository, teamRepo *MockTeamRepository) {
+				repoRepo.On("RepositoryExists", mock.Anything, "backend").Return(false, nil)
+				teamRepo.On("TeamExists", mock.Anything, "ghost").Return(false, nil)
+			},
+			expectedError: "NOT_FOUND",
+		},
+		{
+			name:       "add repository error",
+			repository: &entity.Repository{RepositoryName: "backend", VCS: "hg"},
+			setupMocks: func(repoRepo *MockRepositoryRepository, _ *MockTeamRepository) {
+				repoRepo.On("RepositoryExists", mock.Anything, "backend").Return(false, nil)
+				repoRepo.On("AddRepository", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoRepo := new(MockRepositoryRepository)
+			prRepo := new(MockPullRequestRepository)
+			teamRepo := new(MockTeamRepository)
+
+			tt.setupMocks(repoRepo, teamRepo)
+
+			svc := NewRepositoryService(repoRepo, prRepo, teamRepo)
+
+			repository, err := svc.AddRepository(t.Context(), tt.repository)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, repository)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedVCS, repository.VCS)
+			}
+
+			repoRepo.AssertExpectations(t)
+			teamRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryService_ListPullRequests(t *testing.T) {
+	t.Run("unknown repository returns NOT_FOUND", func(t *testing.T) {
+		repoRepo := new(MockRepositoryRepository)
+		repoRepo.On("RepositoryExists", mock.Anything, "ghost").Return(false, nil)
+
+		svc := NewRepositoryService(repoRepo, new(MockPullRequestRepository), new(MockTeamRepository))
+
+		prs, err := svc.ListPullRequests(t.Context(), "ghost", entity.OPEN)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, prs)
+	})
+
+	t.Run("lists PRs filtered by status", func(t *testing.T) {
+		repoRepo := new(MockRepositoryRepository)
+		prRepo := new(MockPullRequestRepository)
+		repoRepo.On("RepositoryExists", mock.Anything, "backend").Return(true, nil)
+		prRepo.On("ListPRsByRepository", mock.Anything, "backend", entity.OPEN).
+			Return([]*entity.PullRequestShort{
+				{PullRequestID: "backend#1", RepositoryName: "backend", Number: 1, Status: entity.OPEN},
+			}, nil)
+
+		svc := NewRepositoryService(repoRepo, prRepo, new(MockTeamRepository))
+
+		prs, err := svc.ListPullRequests(t.Context(), "backend", entity.OPEN)
+		assert.NoError(t, err)
+		assert.Len(t, prs, 1)
+		assert.Equal(t, 1, prs[0].Number)
+
+		prRepo.AssertExpectations(t)
+	})
+}
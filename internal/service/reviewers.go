@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+// AddReviewer assigns one more reviewer to an open PR that has a free slot.
+// An empty userID picks one from the reviewing team like creation does;
+// otherwise the user must be an active member of that team, not the author,
+// not in conflict with the author and not already assigned, and must pass the
+// capacity and unavailability filter the automatic picks use.
+func (s *PRService) AddReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*entity.PullRequest, string, error) {
+	ctx, span := tracing.Start(ctx, "PRService.AddReviewer")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
+	defer cancel()
+
+	pr, err := s.openPR(queryCtx, prID)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	if len(pr.AssignedReviewers) >= maxReviewers {
+		return nil, emptyString, entity.ErrReviewerLimit
+	}
+
+	team, err := s.reviewTeam(queryCtx, pr)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	conflicting, err := s.conflictsOf(queryCtx, pr.AuthorID)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	// stays nil for an explicitly chosen reviewer, which is not a pick
+	var decision *entity.AssignmentDecision
+
+	if userID == emptyString {
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		exclude = append(exclude, conflicting...)
+
+		candidates, err := s.userRepo.GetActiveUsersByTeam(queryCtx, team, exclude)
+		if err != nil {
+			return nil, emptyString, err
+		}
+
+		pairings, err := s.recentPairings(queryCtx, pr.AuthorID)
+		if err != nil {
+			return nil, emptyString, err
+		}
+
+		var picked []*entity.User
+
+		picked, decision = s.pickReviewers(operationReassign, candidates, 1, pairings)
+		if len(picked) == zeroLength {
+			observeAssignment(queryCtx, decision)
+			return nil, emptyString, entity.ErrNoCandidate
+		}
+
+		userID = picked[0].UserID
+	} else if err := s.checkReviewer(queryCtx, pr, team, userID, conflicting); err != nil {
+		return nil, emptyString, err
+	} else if err := s.checkCanTakeReview(queryCtx, userID); err != nil {
+		return nil, emptyString, err
+	}
+
+	reviewers := append(slices.Clone(pr.AssignedReviewers), userID)
+
+	err = s.withinTx(queryCtx, false, func(ctx context.Context) error {
+		if err := s.repo.UpdateReviewers(ctx, prID, reviewers); err != nil {
+			return err
+		}
+
+		observeAssignment(ctx, decision)
+
+		return s.recordDecision(ctx, prID, decision)
+	})
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	updatedPR, err := s.repo.GetPR(queryCtx, prID)
+	if err != nil {
+		return nil, emptyString, err
+	}
+
+	return updatedPR, userID, nil
+}
+
+// RemoveReviewer takes userID off an open PR without assigning anyone
+// instead.
+func (s *PRService) RemoveReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*entity.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PRService.RemoveReviewer")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, prQueryTimeout)
+	defer cancel()
+
+	// the PR is read and written in one transaction so a concurrent change
+	// of its reviewers is not overwritten
+	err := s.withinTx(queryCtx, false, func(ctx context.Context) error {
+		pr, err := s.openPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(pr.AssignedReviewers, userID) {
+			return entity.ErrNotAssigned
+		}
+
+		reviewers := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool {
+			return id == userID
+		})
+
+		return s.repo.UpdateReviewers(ctx, prID, reviewers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPR, err := s.repo.GetPR(queryCtx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedPR, nil
+}
+
+func (s *PRService) openPR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := s.repo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+
+	if pr.Status == entity.MERGED {
+		return nil, entity.ErrPRMerged
+	}
+
+	return pr, nil
+}
+
+// reviewTeam is the team a PR's reviewers come from: the repository's
+// default team when it has one, otherwise the author's team.
+func (s *PRService) reviewTeam(ctx context.Context, pr *entity.PullRequest) (string, error) {
+	if pr.RepositoryName != emptyString && s.repoRepo != nil {
+		repository, err := s.repoRepo.GetRepository(ctx, pr.RepositoryName)
+		if err == nil && repository.DefaultTeam != emptyString {
+			return repository.DefaultTeam, nil
+		}
+	}
+
+	author, err := s.userRepo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return emptyString, entity.ErrNotFound
+	}
+
+	return author.TeamName, nil
+}
+
+func (s *PRService) checkReviewer(
+	ctx context.Context,
+	pr *entity.PullRequest,
+	team, userID string,
+	conflicting []string,
+) error {
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return entity.ErrNotFound
+	}
+
+	switch {
+	case user.TeamName != team:
+		return fmt.Errorf("%w: %s is not a member of team %s", entity.ErrInvalidReviewer, userID, team)
+	case !user.IsActive:
+		return fmt.Errorf("%w: %s is not active", entity.ErrInvalidReviewer, userID)
+	case userID == pr.AuthorID:
+		return fmt.Errorf("%w: author cannot review their own PR", entity.ErrInvalidReviewer)
+	case slices.Contains(conflicting, userID):
+		return fmt.Errorf("%w: %s conflicts with the author", entity.ErrInvalidReviewer, userID)
+	case slices.Contains(pr.AssignedReviewers, userID):
+		return fmt.Errorf("%w: %s is already assigned", entity.ErrInvalidReviewer, userID)
+	}
+
+	return nil
+}
+
+// checkCanTakeReview rejects a user the automatic picks would skip: one
+// inside an unavailability window or already at their open review limit.
+func (s *PRService) checkCanTakeReview(ctx context.Context, userID string) error {
+	ok, err := s.userRepo.CanTakeReview(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s is unavailable or at their review limit", entity.ErrReviewerUnavailable, userID)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestPRService_AddReviewer(t *testing.T) {
+	ctx := t.Context()
+
+	newService := func(reviewers ...string) (*PRService, *MockPullRequestRepository, *MockUserRepository) {
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "author",
+			Status:            entity.OPEN,
+			AssignedReviewers: reviewers,
+		}, nil).Once()
+		userRepo.On("GetUser", mock.Anything, "author").
+			Return(&entity.User{UserID: "author", TeamName: "team1", IsActive: true}, nil)
+
+		return NewPRService(prRepo, userRepo, nil), prRepo, userRepo
+	}
+
+	t.Run("picks a reviewer automatically", func(t *testing.T) {
+		s, prRepo, userRepo := newService("r1")
+
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "r1"}).
+			Return([]*entity.User{{UserID: "r2"}}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"r1", "r2"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AssignedReviewers: []string{"r1", "r2"},
+		}, nil)
+
+		pr, added, err := s.AddReviewer(ctx, "pr1", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "r2", added)
+		assert.Equal(t, []string{"r1", "r2"}, pr.AssignedReviewers)
+	})
+
+	t.Run("adds the given team member", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		userRepo.On("GetUser", mock.Anything, "r1").
+			Return(&entity.User{UserID: "r1", TeamName: "team1", IsActive: true}, nil)
+		userRepo.On("CanTakeReview", mock.Anything, "r1").Return(true, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"r1"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AssignedReviewers: []string{"r1"},
+		}, nil)
+
+		_, added, err := s.AddReviewer(ctx, "pr1", "r1")
+		require.NoError(t, err)
+		assert.Equal(t, "r1", added)
+	})
+
+	t.Run("member who cannot take a review returns REVIEWER_UNAVAILABLE", func(t *testing.T) {
+		s, prRepo, userRepo := newService()
+
+		userRepo.On("GetUser", mock.Anything, "r1").
+			Return(&entity.User{UserID: "r1", TeamName: "team1", IsActive: true}, nil)
+		userRepo.On("CanTakeReview", mock.Anything, "r1").Return(false, nil)
+
+		_, _, err := s.AddReviewer(ctx, "pr1", "r1")
+		assert.ErrorIs(t, err, entity.ErrReviewerUnavailable)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("full PR returns REVIEWER_LIMIT", func(t *testing.T) {
+		s, prRepo, _ := newService("r1", "r2")
+
+		_, _, err := s.AddReviewer(ctx, "pr1", "")
+		assert.ErrorIs(t, err, entity.ErrReviewerLimit)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("no candidate returns NO_CANDIDATE", func(t *testing.T) {
+		s, _, userRepo := newService("r1")
+
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author", "r1"}).
+			Return([]*entity.User{}, nil)
+
+		_, _, err := s.AddReviewer(ctx, "pr1", "")
+		assert.ErrorIs(t, err, entity.ErrNoCandidate)
+	})
+
+	invalid := []struct {
+		user     *entity.User
+		name     string
+		assigned []string
+	}{
+		{name: "member of another team", user: &entity.User{UserID: "r1", TeamName: "team2", IsActive: true}},
+		{name: "inactive member", user: &entity.User{UserID: "r1", TeamName: "team1"}},
+		{
+			name:     "already assigned",
+			user:     &entity.User{UserID: "r1", TeamName: "team1", IsActive: true},
+			assigned: []string{"r1"},
+		},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, userRepo := newService(tt.assigned...)
+			userRepo.On("GetUser", mock.Anything, "r1").Return(tt.user, nil)
+
+			_, _, err := s.AddReviewer(ctx, "pr1", "r1")
+			assert.ErrorIs(t, err, entity.ErrInvalidReviewer)
+		})
+	}
+
+	t.Run("author returns INVALID_REVIEWER", func(t *testing.T) {
+		s, _, _ := newService()
+
+		_, _, err := s.AddReviewer(ctx, "pr1", "author")
+		assert.ErrorIs(t, err, entity.ErrInvalidReviewer)
+	})
+}
+
+func TestPRService_RemoveReviewer(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("removes the reviewer without replacement", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		tx := &MockTransactor{}
+		s := NewPRService(prRepo, nil, nil, WithTransactor(tx))
+
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"r1", "r2"},
+		}, nil).Once()
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"r2"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AssignedReviewers: []string{"r2"},
+		}, nil)
+
+		pr, err := s.RemoveReviewer(ctx, "pr1", "r1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r2"}, pr.AssignedReviewers)
+		assert.Equal(t, 1, tx.Calls, "the read and the write share one transaction")
+	})
+
+	t.Run("reviewer not on the PR returns NOT_ASSIGNED", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		s := NewPRService(prRepo, nil, nil)
+
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"r2"},
+		}, nil)
+
+		_, err := s.RemoveReviewer(ctx, "pr1", "r1")
+		assert.ErrorIs(t, err, entity.ErrNotAssigned)
+	})
+
+	t.Run("merged PR returns PR_MERGED", func(t *testing.T) {
+		prRepo := new(MockPullRequestRepository)
+		s := NewPRService(prRepo, nil, nil)
+
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			Status:            entity.MERGED,
+			AssignedReviewers: []string{"r1"},
+		}, nil)
+
+		_, err := s.RemoveReviewer(ctx, "pr1", "r1")
+		assert.ErrorIs(t, err, entity.ErrPRMerged)
+	})
+}
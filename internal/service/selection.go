@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
+)
+
+// WithWorkingHours makes assignment prefer reviewers who are inside their
+// working window. When not enough of them are available, reviewers whose
+// window opens within sla come next, then everybody else by waiting time.
+func WithWorkingHours(sla time.Duration) PROption {
+	return func(s *PRService) {
+		s.preferWorkingHours = true
+		s.workingHoursSLA = sla
+	}
+}
+
+// WithPairRotation makes assignment prefer reviewers who were assigned to the
+// author's PRs least often within window, so reviews rotate across the team
+// instead of repeating the same author/reviewer pairs. Recent pairs are only
+// ranked last, never excluded; working hours, when enabled, rank first.
+func WithPairRotation(window time.Duration) PROption {
+	return func(s *PRService) {
+		s.pairRotationWindow = window
+	}
+}
+
+// recentPairings counts how often each reviewer was assigned to authorID's
+// PRs within the rotation window; nil when rotation is off.
+func (s *PRService) recentPairings(ctx context.Context, authorID string) (map[string]int, error) {
+	if s.pairRotationWindow <= 0 {
+		return nil, nil
+	}
+
+	return s.repo.GetRecentPairings(ctx, authorID, s.now().Add(-s.pairRotationWindow))
+}
+
+// Operations reported with assignment outcome metrics.
+const (
+	operationCreate   = "create"
+	operationReassign = "reassign"
+)
+
+// WithRandSource makes assignment draw the seed of every pick from src, so a
+// fixed source reproduces a whole run. src is only used under a lock.
+func WithRandSource(src rand.Source) PROption {
+	return func(s *PRService) {
+		var mu sync.Mutex
+
+		// nolint:gosec // don't need cryptographic randomness here
+		seeds := rand.New(src)
+
+		s.nextSeed = func() int64 {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return seeds.Int63()
+		}
+	}
+}
+
+// WithDecisions records the seed and candidates of every automatic pick so
+// it can be replayed and explained later.
+func WithDecisions(r postgres.DecisionRepository) PROption {
+	return func(s *PRService) {
+		s.decisionRepo = r
+	}
+}
+
+// shuffleWithSeed permutes n items through swap. The permutation depends on
+// seed and n only, so replaying it on the recorded user IDs gives the order
+// the users had.
+func shuffleWithSeed(seed int64, n int, swap func(i, j int)) {
+	// nolint:gosec // don't need cryptographic randomness here
+	rand.New(rand.NewSource(seed)).Shuffle(n, swap)
+}
+
+// pickReviewers returns up to count reviewers chosen from candidates.
+// Candidates are shuffled with a fresh seed; those with fewer pairings with
+// the author come first. The returned decision describes the pick for
+// recordDecision and observeAssignment.
+func (s *PRService) pickReviewers(
+	operation string,
+	candidates []*entity.User,
+	count int,
+	pairings map[string]int,
+) ([]*entity.User, *entity.AssignmentDecision) {
+	decision := &entity.AssignmentDecision{
+		DecidedAt:  s.now(),
+		Pairings:   pairings,
+		Operation:  operation,
+		Seed:       s.nextSeed(),
+		Candidates: userIDs(candidates),
+	}
+
+	shuffled := make([]*entity.User, len(candidates))
+	copy(shuffled, candidates)
+	shuffleWithSeed(decision.Seed, len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	if pairings != nil {
+		sort.SliceStable(shuffled, func(i, j int) bool {
+			return pairings[shuffled[i].UserID] < pairings[shuffled[j].UserID]
+		})
+	}
+
+	if s.preferWorkingHours {
+		s.orderByWorkingHours(shuffled, decision.DecidedAt)
+	}
+
+	if len(shuffled) < count {
+		count = len(shuffled)
+	}
+
+	decision.Ranking = userIDs(shuffled)
+	decision.Picked = decision.Ranking[:count]
+
+	return shuffled[:count], decision
+}
+
+// pendingOutcomesKey carries the picks made inside withinTx until its
+// transaction commits.
+type pendingOutcomesKey struct{}
+
+type pendingOutcomes struct {
+	decisions []*entity.AssignmentDecision
+}
+
+// observeAssignment reports the outcome of decision to the metrics. Inside
+// withinTx it is held back until the transaction commits, so dry runs and
+// rolled back changes are not counted.
+func observeAssignment(ctx context.Context, decision *entity.AssignmentDecision) {
+	if decision == nil {
+		return
+	}
+
+	if pending, ok := ctx.Value(pendingOutcomesKey{}).(*pendingOutcomes); ok {
+		pending.decisions = append(pending.decisions, decision)
+		return
+	}
+
+	metrics.ObserveAssignment(decision.Operation, len(decision.Picked))
+}
+
+// recordDecision stores decision as made for prID; callers run it in the
+// transaction that applies the pick. A nil decision means no pick was made.
+func (s *PRService) recordDecision(
+	ctx context.Context,
+	prID string,
+	decision *entity.AssignmentDecision,
+) error {
+	if s.decisionRepo == nil || decision == nil {
+		return nil
+	}
+
+	decision.PullRequestID = prID
+
+	return s.decisionRepo.AddDecision(ctx, decision)
+}
+
+func userIDs(users []*entity.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+
+	return ids
+}
+
+// orderByWorkingHours keeps the previous order inside each tier: reviewers at
+// work, reviewers starting within the SLA, and the rest sorted by wait.
+func (s *PRService) orderByWorkingHours(users []*entity.User, now time.Time) {
+	const (
+		atWork = iota
+		withinSLA
+		outsideSLA
+	)
+
+	wait := make(map[*entity.User]time.Duration, len(users))
+	for _, u := range users {
+		wait[u] = u.UntilWorkingHours(now)
+	}
+
+	tier := func(u *entity.User) int {
+		switch {
+		case wait[u] == 0:
+			return atWork
+		case wait[u] <= s.workingHoursSLA:
+			return withinSLA
+		default:
+			return outsideSLA
+		}
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		ti, tj := tier(users[i]), tier(users[j])
+		if ti != tj {
+			return ti < tj
+		}
+
+		if ti == outsideSLA {
+			return wait[users[i]] < wait[users[j]]
+		}
+
+		return false
+	})
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestUntilWorkingHours(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC) // 18:00 MSK, 08:00 PDT
+
+	tests := []struct {
+		name string
+		user entity.User
+		want time.Duration
+	}{
+		{
+			name: "no working hours configured",
+			user: entity.User{TimeZone: "UTC"},
+			want: 0,
+		},
+		{
+			name: "inside window",
+			user: entity.User{TimeZone: "Europe/Moscow", WorkStart: "10:00", WorkEnd: "19:00"},
+			want: 0,
+		},
+		{
+			name: "before window opens",
+			user: entity.User{TimeZone: "America/Los_Angeles", WorkStart: "09:00", WorkEnd: "18:00"},
+			want: time.Hour,
+		},
+		{
+			name: "after window closed waits until tomorrow",
+			user: entity.User{TimeZone: "Europe/Moscow", WorkStart: "09:00", WorkEnd: "17:00"},
+			want: 15 * time.Hour,
+		},
+		{
+			name: "overnight window",
+			user: entity.User{TimeZone: "UTC", WorkStart: "22:00", WorkEnd: "06:00"},
+			want: 7 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.user.UntilWorkingHours(now))
+		})
+	}
+}
+
+func TestPRService_PickReviewersWorkingHours(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	moscow := &entity.User{UserID: "msk", TimeZone: "Europe/Moscow", WorkStart: "10:00", WorkEnd: "19:00"}
+	soon := &entity.User{UserID: "sf-soon", TimeZone: "America/Los_Angeles", WorkStart: "09:00", WorkEnd: "18:00"}
+	late := &entity.User{UserID: "sf-late", TimeZone: "America/Los_Angeles", WorkStart: "11:00", WorkEnd: "20:00"}
+	asleep := &entity.User{UserID: "tokyo", TimeZone: "Asia/Tokyo", WorkStart: "10:00", WorkEnd: "19:00"}
+
+	t.Run("reviewers at work come first", func(t *testing.T) {
+		s := NewPRService(nil, nil, nil, WithWorkingHours(2*time.Hour))
+		s.now = func() time.Time { return now }
+
+		for range 20 {
+			picked, _ := s.pickReviewers(operationCreate, []*entity.User{asleep, late, soon, moscow}, 2, nil)
+			assert.Equal(t, []*entity.User{moscow, soon}, picked)
+		}
+	})
+
+	t.Run("nobody at work falls back to the shortest wait", func(t *testing.T) {
+		s := NewPRService(nil, nil, nil, WithWorkingHours(30*time.Minute))
+		s.now = func() time.Time { return now }
+
+		picked, _ := s.pickReviewers(operationCreate, []*entity.User{asleep, late, soon}, 1, nil)
+		assert.Equal(t, []*entity.User{soon}, picked)
+	})
+
+	t.Run("without the option every candidate can be picked", func(t *testing.T) {
+		s := NewPRService(nil, nil, nil)
+
+		picked, _ := s.pickReviewers(operationCreate, []*entity.User{asleep, moscow}, 5, nil)
+		assert.Len(t, picked, 2)
+	})
+}
+
+func TestPRService_PickReviewersPairRotation(t *testing.T) {
+	frequent := &entity.User{UserID: "frequent"}
+	occasional := &entity.User{UserID: "occasional"}
+	fresh := &entity.User{UserID: "fresh"}
+	pairings := map[string]int{"frequent": 5, "occasional": 1}
+
+	t.Run("reviewers paired least with the author come first", func(t *testing.T) {
+		s := NewPRService(nil, nil, nil, WithPairRotation(30*24*time.Hour))
+
+		for range 20 {
+			picked, _ := s.pickReviewers(operationCreate, []*entity.User{frequent, occasional, fresh}, 2, pairings)
+			assert.Equal(t, []*entity.User{fresh, occasional}, picked)
+		}
+	})
+
+	t.Run("working hours rank before rotation", func(t *testing.T) {
+		now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+		atWork := &entity.User{UserID: "frequent", TimeZone: "Europe/Moscow", WorkStart: "10:00", WorkEnd: "19:00"}
+		asleep := &entity.User{UserID: "fresh", TimeZone: "Asia/Tokyo", WorkStart: "10:00", WorkEnd: "19:00"}
+
+		s := NewPRService(nil, nil, nil, WithWorkingHours(time.Hour), WithPairRotation(30*24*time.Hour))
+		s.now = func() time.Time { return now }
+
+		picked, _ := s.pickReviewers(operationCreate, []*entity.User{asleep, atWork}, 1, pairings)
+		assert.Equal(t, []*entity.User{atWork}, picked)
+	})
+
+	t.Run("creation counts pairings within the window", func(t *testing.T) {
+		now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+		window := 14 * 24 * time.Hour
+
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+		teamRepo := new(MockTeamRepository)
+
+		prRepo.On("PRExists", mock.Anything, "pr-1").Return(false, nil)
+		userRepo.On("GetUser", mock.Anything, "author").
+			Return(&entity.User{UserID: "author", TeamName: "team1"}, nil)
+		teamRepo.On("GetTeam", mock.Anything, "team1").Return(&entity.Team{TeamName: "team1"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"author"}).
+			Return([]*entity.User{frequent, occasional, fresh}, nil)
+		prRepo.On("GetRecentPairings", mock.Anything, "author", now.Add(-window)).Return(pairings, nil)
+		prRepo.On("CreatePR", mock.Anything, mock.Anything, []string{"fresh", "occasional"}).Return(nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{PullRequestID: "pr-1"}, nil)
+
+		s := NewPRService(prRepo, userRepo, teamRepo, WithPairRotation(window))
+		s.now = func() time.Time { return now }
+
+		_, _, err := s.CreatePR(t.Context(), entity.PRCreateParams{PullRequestID: "pr-1", AuthorID: "author"})
+		require.NoError(t, err)
+		prRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	//nolint:revive // necessary import
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+)
+
+const (
+	slaQueryTimeout = 500 * time.Millisecond
+	slaCheckTimeout = 5 * time.Second
+)
+
+type SLAService struct {
+	repo      postgres.SLARepository
+	prRepo    postgres.PullRequestRepository
+	prService *PRService
+	action    entity.SLAAction
+}
+
+func NewSLAService(repo postgres.SLARepository,
+	prRepo postgres.PullRequestRepository,
+	prService *PRService,
+	action entity.SLAAction) *SLAService {
+	return &SLAService{
+		repo:      repo,
+		prRepo:    prRepo,
+		prService: prService,
+		action:    action,
+	}
+}
+
+// GetOverdue lists every overdue review together with counts per team.
+func (s *SLAService) GetOverdue(ctx context.Context) (*entity.OverdueReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, slaQueryTimeout)
+	defer cancel()
+
+	reviews, err := s.repo.GetOverdueReviews(queryCtx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.OverdueReport{
+		PerTeam: make(map[string]int),
+		Reviews: reviews,
+	}
+
+	for _, review := range reviews {
+		report.PerTeam[review.TeamName]++
+	}
+
+	return report, nil
+}
+
+// CheckOverdue applies the configured action to reviews that became overdue
+// since the previous run and returns how many were handled. Each review is
+// handled once: it is flagged afterwards, or replaced when reassigned.
+//
+//nolint:revive // func
+func (s *SLAService) CheckOverdue(ctx context.Context) (int, error) {
+	jobCtx, cancel := context.WithTimeout(ctx, slaCheckTimeout)
+	defer cancel()
+
+	reviews, err := s.repo.GetOverdueReviews(jobCtx, true)
+	if err != nil {
+		return Empty, err
+	}
+
+	var errs []error
+
+	handled := Empty
+
+	for _, review := range reviews {
+		replaced, err := s.apply(jobCtx, review)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !replaced {
+			if err := s.repo.MarkOverdueFlagged(jobCtx, review.PullRequestID, review.ReviewerID); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		handled++
+	}
+
+	return handled, errors.Join(errs...)
+}
+
+// apply reports whether the overdue reviewer was removed from the PR.
+func (s *SLAService) apply(ctx context.Context, review *entity.OverdueReview) (bool, error) {
+	switch s.action {
+	case entity.SLAActionReassign:
+		_, _, err := s.prService.ReassignReviewer(ctx, review.PullRequestID, review.ReviewerID)
+		// nobody to take over: keep the reviewer and just flag the review
+		if errors.Is(err, entity.ErrNoCandidate) {
+			return false, nil
+		}
+
+		return err == nil, err
+	case entity.SLAActionEscalate:
+		return s.escalate(ctx, review)
+	default:
+		return false, nil
+	}
+}
+
+// escalate brings the team lead onto the PR: as an extra reviewer while the
+// PR has a free slot, otherwise in place of the overdue reviewer, which is
+// then reported as replaced. The lead goes through the same checks as an
+// explicitly added reviewer; a lead who fails them is not assigned and the
+// review is only flagged.
+func (s *SLAService) escalate(ctx context.Context, review *entity.OverdueReview) (bool, error) {
+	lead := review.LeadUserID
+	if lead == "" {
+		return false, nil
+	}
+
+	replaced := false
+
+	err := s.prService.withinTx(ctx, false, func(ctx context.Context) error {
+		pr, err := s.prRepo.GetPR(ctx, review.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(pr.AssignedReviewers, lead) {
+			return nil
+		}
+
+		conflicting, err := s.prService.conflictsOf(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.prService.checkReviewer(ctx, pr, review.TeamName, lead, conflicting); err != nil {
+			return err
+		}
+
+		if err := s.prService.checkCanTakeReview(ctx, lead); err != nil {
+			return err
+		}
+
+		reviewers := append(slices.Clone(pr.AssignedReviewers), lead)
+		if len(pr.AssignedReviewers) >= maxReviewers {
+			i := slices.Index(pr.AssignedReviewers, review.ReviewerID)
+			if i < 0 {
+				// the overdue reviewer already left the PR
+				return nil
+			}
+
+			reviewers = slices.Clone(pr.AssignedReviewers)
+			reviewers[i] = lead
+			replaced = true
+		}
+
+		return s.prRepo.UpdateReviewers(ctx, pr.PullRequestID, reviewers)
+	})
+	// the lead cannot take the review: keep the reviewer and just flag it
+	if errors.Is(err, entity.ErrInvalidReviewer) || errors.Is(err, entity.ErrReviewerUnavailable) {
+		return false, nil
+	}
+
+	return replaced, err
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func overdueReview() *entity.OverdueReview {
+	return &entity.OverdueReview{
+		PullRequestID: "pr1",
+		AuthorID:      "u1",
+		ReviewerID:    "u2",
+		TeamName:      "team1",
+		LeadUserID:    "lead",
+		SLAMinutes:    60,
+	}
+}
+
+func TestSLAService_GetOverdue(t *testing.T) {
+	repo := new(MockSLARepository)
+	repo.On("GetOverdueReviews", mock.Anything, false).Return([]*entity.OverdueReview{
+		{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "team1"},
+		{PullRequestID: "pr2", ReviewerID: "u3", TeamName: "team1"},
+		{PullRequestID: "pr3", ReviewerID: "u4", TeamName: "team2"},
+	}, nil)
+
+	svc := NewSLAService(repo, new(MockPullRequestRepository), nil, entity.SLAActionFlag)
+
+	report, err := svc.GetOverdue(t.Context())
+	assert.NoError(t, err)
+	assert.Len(t, report.Reviews, 3)
+	assert.Equal(t, map[string]int{"team1": 2, "team2": 1}, report.PerTeam)
+}
+
+func TestSLAService_CheckOverdue(t *testing.T) {
+	t.Run("flag only marks the review", func(t *testing.T) {
+		repo := new(MockSLARepository)
+		prRepo := new(MockPullRequestRepository)
+		repo.On("GetOverdueReviews", mock.Anything, true).
+			Return([]*entity.OverdueReview{overdueReview()}, nil)
+		repo.On("MarkOverdueFlagged", mock.Anything, "pr1", "u2").Return(nil)
+
+		svc := NewSLAService(repo, prRepo, nil, entity.SLAActionFlag)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		repo.AssertExpectations(t)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassign replaces the reviewer", func(t *testing.T) {
+		repo := new(MockSLARepository)
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, userRepo, new(MockTeamRepository))
+
+		repo.On("GetOverdueReviews", mock.Anything, true).
+			Return([]*entity.OverdueReview{overdueReview()}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "u1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").Return(&entity.User{UserID: "u2", TeamName: "team1"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"u1", "u2"}).
+			Return([]*entity.User{{UserID: "u3", TeamName: "team1", IsActive: true}}, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"u3"}).Return(nil)
+
+		svc := NewSLAService(repo, prRepo, prService, entity.SLAActionReassign)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		prRepo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "MarkOverdueFlagged", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassign without candidates keeps and flags the reviewer", func(t *testing.T) {
+		repo := new(MockSLARepository)
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		prService := NewPRService(prRepo, userRepo, new(MockTeamRepository))
+
+		repo.On("GetOverdueReviews", mock.Anything, true).
+			Return([]*entity.OverdueReview{overdueReview()}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "u1",
+			Status:            entity.OPEN,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").Return(&entity.User{UserID: "u2", TeamName: "team1"}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"u1", "u2"}).
+			Return([]*entity.User{}, nil)
+		repo.On("MarkOverdueFlagged", mock.Anything, "pr1", "u2").Return(nil)
+
+		svc := NewSLAService(repo, prRepo, prService, entity.SLAActionReassign)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		repo.AssertExpectations(t)
+	})
+
+	escalation := func(
+		reviewers []string,
+		lead *entity.User,
+	) (*SLAService, *MockSLARepository, *MockPullRequestRepository, *MockUserRepository) {
+		repo := new(MockSLARepository)
+		prRepo := new(MockPullRequestRepository)
+		userRepo := new(MockUserRepository)
+		prService := NewPRService(prRepo, userRepo, new(MockTeamRepository))
+
+		repo.On("GetOverdueReviews", mock.Anything, true).
+			Return([]*entity.OverdueReview{overdueReview()}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AuthorID:          "u1",
+			Status:            entity.OPEN,
+			AssignedReviewers: reviewers,
+		}, nil)
+		userRepo.On("GetUser", mock.Anything, "lead").Return(lead, nil)
+
+		return NewSLAService(repo, prRepo, prService, entity.SLAActionEscalate), repo, prRepo, userRepo
+	}
+
+	activeLead := &entity.User{UserID: "lead", TeamName: "team1", IsActive: true}
+
+	t.Run("escalate adds the team lead", func(t *testing.T) {
+		svc, repo, prRepo, userRepo := escalation([]string{"u2"}, activeLead)
+
+		userRepo.On("CanTakeReview", mock.Anything, "lead").Return(true, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"u2", "lead"}).Return(nil)
+		repo.On("MarkOverdueFlagged", mock.Anything, "pr1", "u2").Return(nil)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		prRepo.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("escalate on a full PR replaces the overdue reviewer", func(t *testing.T) {
+		svc, repo, prRepo, userRepo := escalation([]string{"u2", "u3"}, activeLead)
+
+		userRepo.On("CanTakeReview", mock.Anything, "lead").Return(true, nil)
+		prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"lead", "u3"}).Return(nil)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		prRepo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "MarkOverdueFlagged", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("escalate to an inactive lead only flags the review", func(t *testing.T) {
+		svc, repo, prRepo, _ := escalation([]string{"u2"}, &entity.User{UserID: "lead", TeamName: "team1"})
+
+		repo.On("MarkOverdueFlagged", mock.Anything, "pr1", "u2").Return(nil)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		repo.AssertExpectations(t)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("escalate to a lead at their limit only flags the review", func(t *testing.T) {
+		svc, repo, prRepo, userRepo := escalation([]string{"u2", "u3"}, activeLead)
+
+		userRepo.On("CanTakeReview", mock.Anything, "lead").Return(false, nil)
+		repo.On("MarkOverdueFlagged", mock.Anything, "pr1", "u2").Return(nil)
+
+		handled, err := svc.CheckOverdue(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handled)
+		repo.AssertExpectations(t)
+		prRepo.AssertNotCalled(t, "UpdateReviewers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTeamService_SetReviewSLA(t *testing.T) {
+	sla := 120
+
+	t.Run("lead must be a team member", func(t *testing.T) {
+		teamRepo := new(MockTeamRepository)
+		teamRepo.On("GetTeam", mock.Anything, "team1").Return(&entity.Team{
+			TeamName: "team1",
+			Members:  []entity.TeamMember{{UserID: "u1"}},
+		}, nil)
+
+		svc := NewTeamService(teamRepo)
+
+		team, err := svc.SetReviewSLA(t.Context(), "team1", &sla, "outsider")
+		assert.ErrorIs(t, err, entity.ErrLeadNotMember)
+		assert.Nil(t, team)
+		teamRepo.AssertNotCalled(t, "SetReviewSLA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stores sla and lead", func(t *testing.T) {
+		teamRepo := new(MockTeamRepository)
+		teamRepo.On("GetTeam", mock.Anything, "team1").Return(&entity.Team{
+			TeamName: "team1",
+			Members:  []entity.TeamMember{{UserID: "u1"}},
+		}, nil)
+		teamRepo.On("SetReviewSLA", mock.Anything, "team1", &sla, "u1").Return(nil)
+
+		svc := NewTeamService(teamRepo)
+
+		team, err := svc.SetReviewSLA(t.Context(), "team1", &sla, "u1")
+		assert.NoError(t, err)
+		assert.Equal(t, "u1", team.LeadUserID)
+		assert.Equal(t, 120, *team.ReviewSLAMinutes)
+	})
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 
 	//nolint:revive // import
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
@@ -20,7 +23,47 @@ func (s *StatsService) GetAssignedCountPerPR(ctx context.Context) (map[string]in
 	return s.statsRepo.GetAssignedReviewersCountPerPR(ctx)
 }
 
+// GetOpenPRCountByReviewerCount maps a number of reviewers to how many open
+// PRs have it.
+func (s *StatsService) GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error) {
+	return s.statsRepo.GetOpenPRCountByReviewerCount(ctx)
+}
+
 //nolint:revive // monolith func
 func (s *StatsService) GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error) {
 	return s.statsRepo.GetOpenPRCountPerUser(ctx)
 }
+
+//nolint:revive // monolith func
+func (s *StatsService) GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error) {
+	return s.statsRepo.GetOpenPRCountPerRepository(ctx)
+}
+
+//nolint:revive // monolith func
+func (s *StatsService) GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error) {
+	return s.statsRepo.GetTeamLoad(ctx)
+}
+
+//nolint:revive // monolith func
+func (s *StatsService) GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error) {
+	return s.statsRepo.GetOverdueCountPerTeam(ctx)
+}
+
+// GetTurnaround reports assignment, merge and review timings for the period.
+func (s *StatsService) GetTurnaround(
+	ctx context.Context,
+	from, to time.Time,
+	groupBy entity.TurnaroundGroupBy,
+) (*entity.TurnaroundReport, error) {
+	groups, err := s.statsRepo.GetTurnaround(ctx, from, to, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TurnaroundReport{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Groups:  groups,
+	}, nil
+}
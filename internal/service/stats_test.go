@@ -5,9 +5,12 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 )
 
 type MockStatsRepo struct {
@@ -26,6 +29,18 @@ func (m *MockStatsRepo) GetAssignedReviewersCountPerPR(ctx context.Context) (map
 	return v, args.Error(1)
 }
 
+func (m *MockStatsRepo) GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).(map[int]int)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
 func (m *MockStatsRepo) GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -38,6 +53,74 @@ func (m *MockStatsRepo) GetOpenPRCountPerUser(ctx context.Context) (map[string]i
 	return v, args.Error(1)
 }
 
+func (m *MockStatsRepo) GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).(map[string]int)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetTurnaround(
+	ctx context.Context,
+	from, to time.Time,
+	groupBy entity.TurnaroundGroupBy,
+) ([]*entity.TurnaroundGroup, error) {
+	args := m.Called(ctx, from, to, groupBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.TurnaroundGroup)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetFairnessData(
+	ctx context.Context,
+	teamName string,
+	from, to time.Time,
+) ([]*entity.FairnessMember, error) {
+	args := m.Called(ctx, teamName, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.FairnessMember)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.TeamLoad)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).(map[string]int)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
 func TestStatsService_Getters(t *testing.T) {
 	ctx := t.Context()
 	mockRepo := new(MockStatsRepo)
@@ -81,3 +164,26 @@ func TestStatsService_ErrorPropagation(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestStatsService_GetTurnaround(t *testing.T) {
+	mockRepo := new(MockStatsRepo)
+	svc := NewStatsService(mockRepo)
+
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	median := 3600.0
+
+	mockRepo.On("GetTurnaround", mock.Anything, from, to, entity.GroupByUser).
+		Return([]*entity.TurnaroundGroup{
+			{Key: "u1", TimeToMerge: entity.DurationStats{Count: 2, MedianSeconds: &median}},
+		}, nil)
+
+	report, err := svc.GetTurnaround(t.Context(), from, to, entity.GroupByUser)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.GroupByUser, report.GroupBy)
+	assert.Equal(t, from, report.From)
+	assert.Len(t, report.Groups, 1)
+	assert.Equal(t, median, *report.Groups[0].TimeToMerge.MedianSeconds)
+
+	mockRepo.AssertExpectations(t)
+}
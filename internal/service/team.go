@@ -28,6 +28,10 @@ func (s *TeamService) AddTeam(ctx context.Context, team *entity.Team) (*entity.T
 	queryCtx, cancel := context.WithTimeout(ctx, teamQueryTimeout)
 	defer cancel()
 
+	if team.LeadUserID != "" && !hasMember(team, team.LeadUserID) {
+		return nil, entity.ErrLeadNotMember
+	}
+
 	exists, err := s.repo.TeamExists(queryCtx, team.TeamName)
 	if err != nil {
 		return nil, err
@@ -61,3 +65,51 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*entity.Tea
 
 	return team, nil
 }
+
+// SetReviewSLA changes how long reviews of the team may stay open and who
+// overdue reviews are escalated to. A nil SLA disables tracking.
+func (s *TeamService) SetReviewSLA(
+	ctx context.Context,
+	teamName string,
+	slaMinutes *int,
+	leadUserID string,
+) (*entity.Team, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, teamQueryTimeout)
+	defer cancel()
+
+	team, err := s.repo.GetTeam(queryCtx, teamName)
+	if err != nil {
+		if err.Error() == notFoundErr {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if leadUserID != "" && !hasMember(team, leadUserID) {
+		return nil, entity.ErrLeadNotMember
+	}
+
+	if err := s.repo.SetReviewSLA(queryCtx, teamName, slaMinutes, leadUserID); err != nil {
+		if err.Error() == notFoundErr {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	team.ReviewSLAMinutes = slaMinutes
+	team.LeadUserID = leadUserID
+
+	return team, nil
+}
+
+func hasMember(team *entity.Team, userID string) bool {
+	for _, member := range team.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
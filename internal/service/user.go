@@ -3,18 +3,20 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	//nolint:revive // necessary import
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/repository/postgres"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
 )
 
 const (
 	userQueryTimeout       = 300 * time.Millisecond
 	userGetPRsQueryTimeout = 250 * time.Millisecond
-	maxPRsToProcess        = 5
-	reassignTimeout        = 100 * time.Millisecond
+	massDeactivateTimeout  = 5 * time.Second
 	Empty                  = 0
 )
 
@@ -37,60 +39,80 @@ func NewUserService(repo postgres.UserRepository,
 	}
 }
 
-//nolint:gocognit,nestif,revive,cyclop // Complex business logic for user activation status change
+// ChangeStatus sets the user's activity. Deactivating an active user takes
+// them off all their open reviews in the same transaction, as MassDeactivate
+// does, and the report lists the reviewer changes.
 func (s *UserService) ChangeStatus(ctx context.Context,
-	userID string, isActive bool) (*entity.User, error) {
-	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+	userID string, isActive bool) (*entity.User, *entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangeStatus")
+	defer span.End()
+
+	return s.changeStatus(ctx, userID, isActive, false)
+}
+
+func (s *UserService) GetPRsAssignedTo(
+	ctx context.Context,
+	userID string,
+) (string, []*entity.PullRequestShort, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetPRsAssignedTo")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, userGetPRsQueryTimeout)
 	defer cancel()
 
-	user, e := s.repo.GetUser(queryCtx, userID)
-	if e != nil {
-		return nil, entity.ErrNotFound
+	_, err := s.repo.GetUser(queryCtx, userID)
+	if err != nil {
+		return "", nil, entity.ErrNotFound
 	}
 
-	if !isActive && user.IsActive {
-		openPRs, err := s.prRepo.GetOpenPRsByReviewer(queryCtx, userID)
-		if err != nil {
-			return nil, err
-		}
+	prs, err := s.repo.GetPRsForReviewer(queryCtx, userID)
+	if err != nil {
+		return "", nil, err
+	}
 
-		maxPRs := maxPRsToProcess
-		if len(openPRs) > maxPRs {
-			openPRs = openPRs[:maxPRs]
-		}
+	return userID, prs, nil
+}
 
-		for _, prID := range openPRs {
-			reassignCtx, reassignCancel := context.WithTimeout(queryCtx, reassignTimeout)
-			_, _, err := s.prService.ReassignReviewer(reassignCtx, prID, userID)
+func (s *UserService) GetReviewCapacity(
+	ctx context.Context,
+	userID string,
+) (*entity.ReviewCapacity, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetReviewCapacity")
+	defer span.End()
 
-			reassignCancel()
+	queryCtx, cancel := context.WithTimeout(ctx, userGetPRsQueryTimeout)
+	defer cancel()
 
-			if err != nil {
-				pr, err := s.prRepo.GetPR(queryCtx, prID)
-				if err == nil {
-					newReviewers := []string{}
+	capacity, err := s.repo.GetReviewCapacity(queryCtx, userID)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
 
-					for _, reviewerID := range pr.AssignedReviewers {
-						if reviewerID != userID {
-							newReviewers = append(newReviewers, reviewerID)
-						}
-					}
+	return capacity, nil
+}
 
-					if err := s.prRepo.UpdateReviewers(queryCtx, prID, newReviewers); err != nil {
-						// Log error but continue processing other PRs.
-						_ = err
-					}
-				}
-			}
+// SetMaxOpenReviews sets the user's review limit; nil falls back to the team
+// default.
+func (s *UserService) SetMaxOpenReviews(
+	ctx context.Context,
+	userID string,
+	maxOpenReviews *int,
+) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetMaxOpenReviews")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+	defer cancel()
+
+	if err := s.repo.SetMaxOpenReviews(queryCtx, userID, maxOpenReviews); err != nil {
+		if err.Error() == notFoundErr {
+			return nil, entity.ErrNotFound
 		}
-	}
 
-	err := s.repo.SetIsActive(queryCtx, userID, isActive)
-	if err != nil {
 		return nil, err
 	}
 
-	user, err = s.repo.GetUser(queryCtx, userID)
+	user, err := s.repo.GetUser(queryCtx, userID)
 	if err != nil {
 		return nil, entity.ErrNotFound
 	}
@@ -98,81 +120,280 @@ func (s *UserService) ChangeStatus(ctx context.Context,
 	return user, nil
 }
 
-func (s *UserService) GetPRsAssignedTo(
+// SetWorkingHours stores the user's time zone and working window; empty
+// bounds clear the window so the user is always considered at work.
+func (s *UserService) SetWorkingHours(
 	ctx context.Context,
-	userID string,
-) (string, []*entity.PullRequestShort, error) {
-	queryCtx, cancel := context.WithTimeout(ctx, userGetPRsQueryTimeout)
+	userID, timeZone, workStart, workEnd string,
+) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetWorkingHours")
+	defer span.End()
+
+	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
 	defer cancel()
 
-	_, err := s.repo.GetUser(queryCtx, userID)
-	if err != nil {
-		return "", nil, entity.ErrNotFound
+	if err := s.repo.SetWorkingHours(queryCtx, userID, timeZone, workStart, workEnd); err != nil {
+		if err.Error() == notFoundErr {
+			return nil, entity.ErrNotFound
+		}
+
+		return nil, err
 	}
 
-	prs, err := s.repo.GetPRsForReviewer(queryCtx, userID)
+	user, err := s.repo.GetUser(queryCtx, userID)
 	if err != nil {
-		return "", nil, err
+		return nil, entity.ErrNotFound
 	}
 
-	return userID, prs, nil
+	return user, nil
 }
 
+// MassDeactivate deactivates users from any number of teams in one
+// transaction. Users are grouped by team and, group by group, taken off
+// their open reviews with replacements drawn from that team. Nothing is
+// changed if any step fails.
+//
 //nolint:revive // unnecessary for changes func
 func (s *UserService) MassDeactivate(ctx context.Context,
 	users []entity.User,
-	flag bool) error {
+	flag bool) (*entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "UserService.MassDeactivate")
+	defer span.End()
 
 	if flag {
-		return entity.ErrOnlyDeactivate
+		return nil, entity.ErrOnlyDeactivate
+	}
+
+	return s.deactivate(ctx, users, false)
+}
+
+// PlanMassDeactivate reports what MassDeactivate would change without
+// applying it.
+func (s *UserService) PlanMassDeactivate(
+	ctx context.Context,
+	users []entity.User,
+) (*entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "UserService.PlanMassDeactivate")
+	defer span.End()
+
+	return s.deactivate(ctx, users, true)
+}
+
+// PlanStatusChange returns the user as ChangeStatus would leave them and the
+// reviews that deactivation would move, without applying anything.
+func (s *UserService) PlanStatusChange(
+	ctx context.Context,
+	userID string,
+	isActive bool,
+) (*entity.User, *entity.ReassignmentReport, error) {
+	ctx, span := tracing.Start(ctx, "UserService.PlanStatusChange")
+	defer span.End()
+
+	return s.changeStatus(ctx, userID, isActive, true)
+}
+
+func (s *UserService) changeStatus(
+	ctx context.Context,
+	userID string,
+	isActive, dryRun bool,
+) (*entity.User, *entity.ReassignmentReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+	user, err := s.repo.GetUser(queryCtx, userID)
+	cancel()
+
+	if err != nil {
+		return nil, nil, entity.ErrNotFound
 	}
 
+	report := entity.NewReassignmentReport()
+	report.DryRun = dryRun
+
+	switch {
+	case user.IsActive && !isActive:
+		report, err = s.deactivate(ctx, []entity.User{{UserID: userID}}, dryRun)
+		if err != nil {
+			return nil, nil, err
+		}
+	case !dryRun:
+		queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+		err = s.repo.SetIsActive(queryCtx, userID, isActive)
+		cancel()
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if dryRun {
+		planned := *user
+		planned.IsActive = isActive
+
+		return &planned, report, nil
+	}
+
+	queryCtx, cancel = context.WithTimeout(ctx, userQueryTimeout)
+	defer cancel()
+
+	user, err = s.repo.GetUser(queryCtx, userID)
+	if err != nil {
+		return nil, nil, entity.ErrNotFound
+	}
+
+	return user, report, nil
+}
+
+func (s *UserService) deactivate(
+	ctx context.Context,
+	users []entity.User,
+	dryRun bool,
+) (*entity.ReassignmentReport, error) {
 	if len(users) == Empty {
-		return entity.ErrEmptyRequest
+		return nil, entity.ErrEmptyRequest
 	}
 
-	team := users[Empty].TeamName
-	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+	queryCtx, cancel := context.WithTimeout(ctx, massDeactivateTimeout)
 	defer cancel()
 
-	if team == "" {
-		u, err := s.repo.GetUser(queryCtx, users[0].UserID)
-		if err != nil {
-			return entity.ErrNotFound
+	groups, teams, err := s.groupByTeam(queryCtx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	report := entity.NewReassignmentReport()
+	report.DryRun = dryRun
+
+	err = s.prService.withinTx(queryCtx, dryRun, func(ctx context.Context) error {
+		for _, team := range teams {
+			for _, userID := range groups[team] {
+				if err := s.repo.SetIsActive(ctx, userID, false); err != nil {
+					return err
+				}
+
+				report.DeactivatedUserIDs = append(report.DeactivatedUserIDs, userID)
+			}
+
+			if err := s.prService.reassignAway(ctx, groups[team], report); err != nil {
+				return err
+			}
 		}
-		team = u.TeamName
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	userIDs := make([]string, Empty, len(users))
+	return report, nil
+}
+
+// groupByTeam resolves each user's team and returns the distinct user IDs
+// per team along with the sorted team names. A team given in the request
+// must match the stored one.
+func (s *UserService) groupByTeam(
+	ctx context.Context,
+	users []entity.User,
+) (map[string][]string, []string, error) {
+	groups := make(map[string][]string)
+	seen := make(map[string]bool, len(users))
+
+	var teams []string
+
 	for _, u := range users {
 		if u.UserID == "" {
-			return errors.New("INVALID_USER")
+			return nil, nil, errors.New("INVALID_USER")
 		}
 
-		if u.TeamName != "" {
-			if u.TeamName != team {
-				return entity.ErrUsersFromDifferentTeams
-			}
-		} else {
-			uu, err := s.repo.GetUser(queryCtx, u.UserID)
-			if err != nil {
-				return entity.ErrNotFound
-			}
-			if uu.TeamName != team {
-				return entity.ErrUsersFromDifferentTeams
-			}
+		if seen[u.UserID] {
+			continue
+		}
+
+		seen[u.UserID] = true
+
+		stored, err := s.repo.GetUser(ctx, u.UserID)
+		if err != nil {
+			return nil, nil, entity.ErrNotFound
+		}
+
+		if u.TeamName != "" && u.TeamName != stored.TeamName {
+			return nil, nil, fmt.Errorf("%w: %s is a member of team %s, not %s",
+				entity.ErrTeamMismatch, u.UserID, stored.TeamName, u.TeamName)
+		}
+
+		if _, ok := groups[stored.TeamName]; !ok {
+			teams = append(teams, stored.TeamName)
+		}
+
+		groups[stored.TeamName] = append(groups[stored.TeamName], u.UserID)
+	}
+
+	sort.Strings(teams)
+
+	return groups, teams, nil
+}
+
+// BulkSetIsActive applies each change independently, across any teams, so one
+// failing user does not block the rest. Deactivation reassigns open reviews
+// like ChangeStatus.
+func (s *UserService) BulkSetIsActive(
+	ctx context.Context,
+	changes []entity.StatusChange,
+) *entity.BulkStatusReport {
+	ctx, span := tracing.Start(ctx, "UserService.BulkSetIsActive")
+	defer span.End()
+
+	report := &entity.BulkStatusReport{
+		Results: make([]entity.BulkStatusResult, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		result := s.applyStatusChange(ctx, change)
+		switch result.Status {
+		case entity.BulkStatusUpdated:
+			report.Updated++
+		case entity.BulkStatusUnchanged:
+			report.Unchanged++
+		case entity.BulkStatusNotFound, entity.BulkStatusFailed:
+			report.Failed++
 		}
 
-		userIDs = append(userIDs, u.UserID)
+		report.Results = append(report.Results, result)
 	}
 
-	repoCtx, repoCancel := context.WithTimeout(ctx, reassignTimeout)
-	defer repoCancel()
+	return report
+}
+
+func (s *UserService) applyStatusChange(
+	ctx context.Context,
+	change entity.StatusChange,
+) entity.BulkStatusResult {
+	result := entity.BulkStatusResult{UserID: change.UserID, IsActive: change.IsActive}
+
+	queryCtx, cancel := context.WithTimeout(ctx, userQueryTimeout)
+	user, err := s.repo.GetUser(queryCtx, change.UserID)
+	cancel()
 
-	err := s.repo.MassDeactivateAndReassign(repoCtx, team, userIDs)
 	if err != nil {
-		return err
+		result.Status = entity.BulkStatusNotFound
+		result.Error = string(entity.CodeNotFound)
+
+		return result
+	}
+
+	result.TeamName = user.TeamName
+	if user.IsActive == change.IsActive {
+		result.Status = entity.BulkStatusUnchanged
+
+		return result
 	}
 
-	return nil
+	if _, _, err := s.ChangeStatus(ctx, change.UserID, change.IsActive); err != nil {
+		result.Status = entity.BulkStatusFailed
+		result.Error = err.Error()
+
+		return result
+	}
+
+	result.Status = entity.BulkStatusUpdated
+
+	return result
 }
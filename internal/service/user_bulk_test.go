@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func TestUserService_BulkSetIsActive(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	prRepo := new(MockPullRequestRepository)
+	teamRepo := new(MockTeamRepository)
+
+	userRepo.On("GetUser", mock.Anything, "u1").
+		Return(&entity.User{UserID: "u1", TeamName: "backend", IsActive: false}, nil).Twice()
+	userRepo.On("SetIsActive", mock.Anything, "u1", true).Return(nil)
+	userRepo.On("GetUser", mock.Anything, "u1").
+		Return(&entity.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil).Once()
+
+	userRepo.On("GetUser", mock.Anything, "ghost").Return(nil, errors.New(notFoundErr))
+
+	userRepo.On("GetUser", mock.Anything, "u3").
+		Return(&entity.User{UserID: "u3", TeamName: "frontend", IsActive: true}, nil)
+
+	userRepo.On("GetUser", mock.Anything, "u4").
+		Return(&entity.User{UserID: "u4", TeamName: "frontend", IsActive: false}, nil)
+	userRepo.On("SetIsActive", mock.Anything, "u4", true).Return(errors.New("db down"))
+
+	svc := NewUserService(userRepo, prRepo, teamRepo, NewPRService(prRepo, userRepo, teamRepo))
+
+	report := svc.BulkSetIsActive(t.Context(), []entity.StatusChange{
+		{UserID: "u1", IsActive: true},
+		{UserID: "ghost", IsActive: true},
+		{UserID: "u3", IsActive: true},
+		{UserID: "u4", IsActive: true},
+	})
+
+	assert.Equal(t, []entity.BulkStatusResult{
+		{UserID: "u1", TeamName: "backend", Status: entity.BulkStatusUpdated, IsActive: true},
+		{UserID: "ghost", Status: entity.BulkStatusNotFound, Error: "NOT_FOUND", IsActive: true},
+		{UserID: "u3", TeamName: "frontend", Status: entity.BulkStatusUnchanged, IsActive: true},
+		{UserID: "u4", TeamName: "frontend", Status: entity.BulkStatusFailed, Error: "db down", IsActive: true},
+	}, report.Results)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, 2, report.Failed)
+	userRepo.AssertExpectations(t)
+}
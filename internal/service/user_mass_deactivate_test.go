@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 )
@@ -13,47 +14,101 @@ import (
 func TestUserService_MassDeactivate(t *testing.T) {
 	ctx := t.Context()
 
-	// set up mocks
-	userRepo := new(MockUserRepository)
-	prRepo := new(MockPullRequestRepository)
-	teamRepo := new(MockTeamRepository)
+	newService := func() (*UserService, *MockUserRepository, *MockPullRequestRepository, *MockTransactor) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPullRequestRepository)
+		teamRepo := new(MockTeamRepository)
+		tx := &MockTransactor{}
+		prService := NewPRService(prRepo, userRepo, teamRepo, WithTransactor(tx))
 
-	svc := NewUserService(userRepo, prRepo, teamRepo, nil)
+		return NewUserService(userRepo, prRepo, teamRepo, prService), userRepo, prRepo, tx
+	}
 
 	t.Run("flag true returns ONLY_DEACTIVATE", func(t *testing.T) {
-		err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u1"}}, true)
+		svc, _, _, _ := newService()
+		_, err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u1"}}, true)
 		assert.EqualError(t, err, "ONLY_DEACTIVATE")
 	})
 
 	t.Run("empty request returns EMPTY_REQUEST", func(t *testing.T) {
-		err := svc.MassDeactivate(ctx, []entity.User{}, false)
+		svc, _, _, _ := newService()
+		_, err := svc.MassDeactivate(ctx, []entity.User{}, false)
 		assert.EqualError(t, err, "EMPTY_REQUEST")
 	})
 
-	t.Run("different team in provided users returns DIFFERENT_TEAM", func(t *testing.T) {
-		users := []entity.User{
-			{UserID: "u1", TeamName: "team1"},
-			{UserID: "u2", TeamName: "team2"},
-		}
-		err := svc.MassDeactivate(ctx, users, false)
-		assert.EqualError(t, err, "USERS_FROM_DIFFERENT_TEAMS")
-	})
-
-	t.Run("repo GetUser returns not found when team missing", func(t *testing.T) {
-		users := []entity.User{{UserID: "u1", TeamName: ""}}
+	t.Run("unknown user returns NOT_FOUND", func(t *testing.T) {
+		svc, userRepo, _, tx := newService()
 		userRepo.On("GetUser", mock.Anything, "u1").Return(nil, errors.New("NOT_FOUND"))
-		defer userRepo.AssertExpectations(t)
 
-		err := svc.MassDeactivate(ctx, users, false)
+		_, err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u1"}}, false)
 		assert.EqualError(t, err, "NOT_FOUND")
+		assert.Zero(t, tx.Calls)
+	})
+
+	t.Run("team not matching the stored one returns TEAM_MISMATCH", func(t *testing.T) {
+		svc, userRepo, _, tx := newService()
+		userRepo.On("GetUser", mock.Anything, "u1").
+			Return(&entity.User{UserID: "u1", TeamName: "team1"}, nil)
+
+		_, err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u1", TeamName: "team2"}}, false)
+		assert.ErrorIs(t, err, entity.ErrTeamMismatch)
+		assert.Zero(t, tx.Calls)
+	})
+
+	t.Run("users of different teams are reassigned per team in one transaction", func(t *testing.T) {
+		svc, userRepo, prRepo, tx := newService()
+
+		userRepo.On("GetUser", mock.Anything, "u1").
+			Return(&entity.User{UserID: "u1", TeamName: "team1"}, nil)
+		userRepo.On("GetUser", mock.Anything, "u2").
+			Return(&entity.User{UserID: "u2", TeamName: "team2"}, nil)
+		userRepo.On("SetIsActive", mock.Anything, "u1", false).Return(nil).Once()
+		userRepo.On("SetIsActive", mock.Anything, "u2", false).Return(nil).Once()
+
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u1").Return([]string{"pr-1"}, nil)
+		prRepo.On("GetOpenPRsByReviewer", mock.Anything, "u2").Return([]string{"pr-2"}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-1").Return(&entity.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "a1",
+			AssignedReviewers: []string{"u1", "r1"},
+		}, nil)
+		prRepo.On("GetPR", mock.Anything, "pr-2").Return(&entity.PullRequest{
+			PullRequestID:     "pr-2",
+			AuthorID:          "a2",
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", []string{"a1", "u1", "r1", "u1"}).
+			Return([]*entity.User{{UserID: "c1", TeamName: "team1"}}, nil)
+		userRepo.On("GetActiveUsersByTeam", mock.Anything, "team2", []string{"a2", "u2", "u2"}).
+			Return([]*entity.User{}, nil)
+
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-1", []string{"c1", "r1"}).Return(nil).Once()
+		prRepo.On("UpdateReviewers", mock.Anything, "pr-2", []string{}).Return(nil).Once()
+
+		report, err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u2"}, {UserID: "u1"}, {UserID: "u1"}}, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, tx.Calls)
+		assert.Equal(t, []string{"u1", "u2"}, report.DeactivatedUserIDs)
+		require.Len(t, report.PullRequests, 2)
+		assert.Equal(t, []string{"c1"}, report.PullRequests[0].Added)
+		assert.Equal(t, []string{"u2"}, report.PullRequests[1].Removed)
+		assert.Equal(t, []string{"pr-2"}, report.Understaffed)
+		assert.Equal(t, []string{"pr-2"}, report.WithoutReviewers)
+
+		userRepo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
 	})
 
-	t.Run("successful mass deactivate calls repository with team and ids", func(t *testing.T) {
-		users := []entity.User{{UserID: "u1", TeamName: "team1"}, {UserID: "u2", TeamName: "team1"}}
-		userRepo.On("MassDeactivateAndReassign", mock.Anything, "team1", []string{"u1", "u2"}).Return(nil)
-		defer userRepo.AssertExpectations(t)
+	t.Run("failure inside the transaction is returned", func(t *testing.T) {
+		svc, userRepo, _, tx := newService()
+		userRepo.On("GetUser", mock.Anything, "u1").
+			Return(&entity.User{UserID: "u1", TeamName: "team1"}, nil)
+		userRepo.On("SetIsActive", mock.Anything, "u1", false).Return(errors.New("db down"))
 
-		err := svc.MassDeactivate(ctx, users, false)
-		assert.NoError(t, err)
+		_, err := svc.MassDeactivate(ctx, []entity.User{{UserID: "u1"}}, false)
+		assert.EqualError(t, err, "db down")
+		assert.Equal(t, 1, tx.Calls)
 	})
 }
@@ -13,397 +13,194 @@ import (
 
 //nolint:maintidx // Complex test with many test cases
 func TestUserService_ChangeActivateStatus(t *testing.T) {
+	activeUser := &entity.User{UserID: "user1", Username: "testuser", TeamName: "team1", IsActive: true}
+	inactiveUser := &entity.User{UserID: "user1", Username: "testuser", TeamName: "team1", IsActive: false}
+	now := time.Now()
+
+	openPR := func(prID string, reviewers ...string) *entity.PullRequest {
+		return &entity.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   "Test PR",
+			AuthorID:          "user2",
+			Status:            entity.OPEN,
+			AssignedReviewers: reviewers,
+			CreatedAt:         &now,
+		}
+	}
+
 	tests := []struct {
-		setupMocks    func(*MockUserRepository, *MockPullRequestRepository, *MockTeamRepository, *PRService)
-		expectedUser  *entity.User
-		name          string
-		userID        string
-		expectedError string
-		isActive      bool
+		setupMocks     func(*MockUserRepository, *MockPullRequestRepository)
+		expectedUser   *entity.User
+		checkReport    func(*testing.T, *entity.ReassignmentReport)
+		name           string
+		userID         string
+		expectedError  string
+		isActive       bool
+		expectedTxRuns int
 	}{
 		{
 			name:     "successful activation",
 			userID:   "user1",
 			isActive: true,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, _ *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 				userRepo.On("SetIsActive", mock.Anything, "user1", true).Return(nil)
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Once()
 			},
-			expectedError: "",
-			expectedUser: &entity.User{
-				UserID:   "user1",
-				Username: "testuser",
-				TeamName: "team1",
-				IsActive: true,
+			expectedUser: activeUser,
+			checkReport: func(t *testing.T, report *entity.ReassignmentReport) {
+				assert.Empty(t, report.PullRequests)
 			},
 		},
 		{
 			name:     "successful deactivation without open PRs",
 			userID:   "user1",
 			isActive: false,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
-				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return([]string{}, nil)
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Twice()
 				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return([]string{}, nil)
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 			},
-			expectedError: "",
-			expectedUser: &entity.User{
-				UserID:   "user1",
-				Username: "testuser",
-				TeamName: "team1",
-				IsActive: false,
+			expectedUser:   inactiveUser,
+			expectedTxRuns: 1,
+			checkReport: func(t *testing.T, report *entity.ReassignmentReport) {
+				assert.Equal(t, []string{"user1"}, report.DeactivatedUserIDs)
+				assert.Empty(t, report.PullRequests)
 			},
 		},
 		{
 			name:     "successful deactivation with open PRs - reassignment success",
 			userID:   "user1",
 			isActive: false,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Times(3)
+				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
 				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return([]string{"pr1", "pr2"}, nil)
-				now := time.Now()
-				prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR 1",
-					AuthorID:          "user2",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{"user1"},
-					CreatedAt:         &now,
-				}, nil).Once()
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
+				prRepo.On("GetPR", mock.Anything, "pr1").Return(openPR("pr1", "user1"), nil)
+				prRepo.On("GetPR", mock.Anything, "pr2").Return(openPR("pr2", "user1"), nil)
 				userRepo.On("GetActiveUsersByTeam",
-					mock.Anything,
-					"team1",
-					[]string{"user2", "user1"}).
+					mock.Anything, "team1", []string{"user2", "user1", "user1"}).
 					Return([]*entity.User{
-						{UserID: "user3", Username: "user3",
-							TeamName: "team1", IsActive: true,
-						},
-					}, nil)
-
-				prRepo.On("UpdateReviewers",
-					mock.Anything, "pr1", mock.Anything).
-					Return(nil)
-
-				prRepo.On("GetPR",
-					mock.Anything, "pr1").Return(&entity.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR 1",
-					AuthorID:          "user2",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{"user3"},
-					CreatedAt:         &now,
-				}, nil).Once()
-
-				prRepo.On("GetPR", mock.Anything, "pr2").
-					Return(&entity.PullRequest{
-						PullRequestID:     "pr2",
-						PullRequestName:   "Test PR 2",
-						AuthorID:          "user2",
-						Status:            entity.OPEN,
-						AssignedReviewers: []string{"user1"},
-						CreatedAt:         &now,
-					}, nil).Once()
-
-				userRepo.On("GetUser", mock.Anything, "user1").
-					Return(&entity.User{
-						UserID:   "user1",
-						Username: "testuser",
-						TeamName: "team1",
-						IsActive: true,
-					}, nil).Once()
-
-				userRepo.On("GetActiveUsersByTeam",
-					mock.Anything, "team1", []string{"user2", "user1"}).
-					Return([]*entity.User{
-						{UserID: "user4", Username: "user4", TeamName: "team1", IsActive: true},
-					}, nil)
-
-				prRepo.On("UpdateReviewers", mock.Anything, "pr2", mock.Anything).Return(nil)
-				prRepo.On("GetPR", mock.Anything, "pr2").Return(&entity.PullRequest{
-					PullRequestID:     "pr2",
-					PullRequestName:   "Test PR 2",
-					AuthorID:          "user2",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{"user4"},
-					CreatedAt:         &now,
-				}, nil).Once()
-				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+						{UserID: "user3", Username: "user3", TeamName: "team1", IsActive: true},
+					}, nil).Twice()
+				prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{"user3"}).Return(nil)
+				prRepo.On("UpdateReviewers", mock.Anything, "pr2", []string{"user3"}).Return(nil)
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 			},
-			expectedError: "",
-			expectedUser: &entity.User{
-				UserID:   "user1",
-				Username: "testuser",
-				TeamName: "team1",
-				IsActive: false,
+			expectedUser:   inactiveUser,
+			expectedTxRuns: 1,
+			checkReport: func(t *testing.T, report *entity.ReassignmentReport) {
+				assert.Len(t, report.PullRequests, 2)
+				assert.Empty(t, report.Understaffed)
 			},
 		},
 		{
-			name:     "successful deactivation with open PRs - reassignment failure, remove reviewer",
+			name:     "successful deactivation with open PRs - no candidate, remove reviewer",
 			userID:   "user1",
 			isActive: false,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Times(3)
+				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
 				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return([]string{"pr1"}, nil)
-				now := time.Now()
-				prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR 1",
-					AuthorID:          "user2",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{"user1"},
-					CreatedAt:         &now,
-				}, nil).Once()
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
-				userRepo.On(
-					"GetActiveUsersByTeam",
-					mock.Anything,
-					"team1",
-					[]string{"user2", "user1"},
-				).Return([]*entity.User{}, nil)
-				prRepo.On("GetPR", mock.Anything, "pr1").Return(&entity.PullRequest{
-					PullRequestID:     "pr1",
-					PullRequestName:   "Test PR 1",
-					AuthorID:          "user2",
-					Status:            entity.OPEN,
-					AssignedReviewers: []string{"user1"},
-					CreatedAt:         &now,
-				}, nil).Once()
+				prRepo.On("GetPR", mock.Anything, "pr1").Return(openPR("pr1", "user1"), nil)
+				userRepo.On("GetActiveUsersByTeam",
+					mock.Anything, "team1", []string{"user2", "user1", "user1"}).
+					Return([]*entity.User{}, nil)
 				prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{}).Return(nil)
-				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 			},
-			expectedError: "",
-			expectedUser: &entity.User{
-				UserID:   "user1",
-				Username: "testuser",
-				TeamName: "team1",
-				IsActive: false,
+			expectedUser:   inactiveUser,
+			expectedTxRuns: 1,
+			checkReport: func(t *testing.T, report *entity.ReassignmentReport) {
+				assert.Equal(t, []string{"pr1"}, report.WithoutReviewers)
 			},
 		},
 		{
 			name:     "user not found",
 			userID:   "user1",
 			isActive: true,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
+			setupMocks: func(userRepo *MockUserRepository, _ *MockPullRequestRepository) {
 				userRepo.On("GetUser", mock.Anything, "user1").Return(nil, errors.New("NOT_FOUND"))
 			},
 			expectedError: "NOT_FOUND",
-			expectedUser:  nil,
 		},
 		{
 			name:     "get open PRs error",
 			userID:   "user1",
 			isActive: false,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Twice()
+				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
 				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return(nil, errors.New("db error"))
 			},
-			expectedError: "db error",
-			expectedUser:  nil,
+			expectedError:  "db error",
+			expectedTxRuns: 1,
+		},
+		{
+			name:     "update reviewers error fails the deactivation",
+			userID:   "user1",
+			isActive: false,
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Times(3)
+				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
+				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return([]string{"pr1"}, nil)
+				prRepo.On("GetPR", mock.Anything, "pr1").Return(openPR("pr1", "user1"), nil)
+				userRepo.On("GetActiveUsersByTeam", mock.Anything, "team1", mock.Anything).
+					Return([]*entity.User{}, nil)
+				prRepo.On("UpdateReviewers", mock.Anything, "pr1", []string{}).Return(errors.New("db error"))
+			},
+			expectedError:  "db error",
+			expectedTxRuns: 1,
 		},
 		{
 			name:     "set is active error",
 			userID:   "user1",
 			isActive: true,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, _ *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 				userRepo.On("SetIsActive", mock.Anything, "user1", true).Return(errors.New("db error"))
 			},
 			expectedError: "db error",
-			expectedUser:  nil,
 		},
 		{
 			name:     "get user after update error",
 			userID:   "user1",
 			isActive: true,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+			setupMocks: func(userRepo *MockUserRepository, _ *MockPullRequestRepository) {
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 				userRepo.On("SetIsActive", mock.Anything, "user1", true).Return(nil)
 				userRepo.On("GetUser", mock.Anything, "user1").Return(nil, errors.New("NOT_FOUND")).Once()
 			},
 			expectedError: "NOT_FOUND",
-			expectedUser:  nil,
 		},
 		{
-			name:     "deactivation with more than 5 open PRs",
+			name:     "deactivation reassigns every open PR",
 			userID:   "user1",
 			isActive: false,
-			setupMocks: func(
-				userRepo *MockUserRepository,
-				prRepo *MockPullRequestRepository,
-				teamRepo *MockTeamRepository,
-				prService *PRService,
-			) {
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: true,
-				}, nil).Once()
-				prRepo.On(
-					"GetOpenPRsByReviewer",
-					mock.Anything,
-					"user1",
-				).Return(
-					[]string{"pr1", "pr2", "pr3", "pr4", "pr5", "pr6", "pr7"},
-					nil,
-				)
-				now := time.Now()
-				prIDs := []string{"pr1", "pr2", "pr3", "pr4", "pr5"}
+			setupMocks: func(userRepo *MockUserRepository, prRepo *MockPullRequestRepository) {
+				prIDs := []string{"pr1", "pr2", "pr3", "pr4", "pr5", "pr6", "pr7"}
+
+				userRepo.On("GetUser", mock.Anything, "user1").Return(activeUser, nil).Times(3)
+				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
+				prRepo.On("GetOpenPRsByReviewer", mock.Anything, "user1").Return(prIDs, nil)
+
 				for _, prID := range prIDs {
-					prRepo.On("GetPR", mock.Anything, prID).Return(&entity.PullRequest{
-						PullRequestID:     prID,
-						PullRequestName:   "Test PR",
-						AuthorID:          "user2",
-						Status:            entity.OPEN,
-						AssignedReviewers: []string{"user1"},
-						CreatedAt:         &now,
-					}, nil).Once()
-					userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-						UserID:   "user1",
-						Username: "testuser",
-						TeamName: "team1",
-						IsActive: true,
-					}, nil).Once()
-					userRepo.On("GetActiveUsersByTeam",
-						mock.Anything, "team1", []string{"user2", "user1"}).
-						Return([]*entity.User{
-							{UserID: "user3", Username: "user3", TeamName: "team1", IsActive: true},
-						}, nil).Once()
-
-					prRepo.On("UpdateReviewers", mock.Anything, prID, mock.Anything).Return(nil).Once()
-					prRepo.On("GetPR", mock.Anything, prID).Return(&entity.PullRequest{
-						PullRequestID:     prID,
-						PullRequestName:   "Test PR",
-						AuthorID:          "user2",
-						Status:            entity.OPEN,
-						AssignedReviewers: []string{"user3"},
-						CreatedAt:         &now,
-					}, nil).Once()
+					prRepo.On("GetPR", mock.Anything, prID).Return(openPR(prID, "user1"), nil).Once()
+					prRepo.On("UpdateReviewers", mock.Anything, prID, []string{"user3"}).Return(nil).Once()
 				}
-				userRepo.On("SetIsActive", mock.Anything, "user1", false).Return(nil)
-				userRepo.On("GetUser", mock.Anything, "user1").Return(&entity.User{
-					UserID:   "user1",
-					Username: "testuser",
-					TeamName: "team1",
-					IsActive: false,
-				}, nil).Once()
+
+				userRepo.On("GetActiveUsersByTeam",
+					mock.Anything, "team1", []string{"user2", "user1", "user1"}).
+					Return([]*entity.User{
+						{UserID: "user3", Username: "user3", TeamName: "team1", IsActive: true},
+					}, nil).Times(len(prIDs))
+				userRepo.On("GetUser", mock.Anything, "user1").Return(inactiveUser, nil).Once()
 			},
-			expectedError: "",
-			expectedUser: &entity.User{
-				UserID:   "user1",
-				Username: "testuser",
-				TeamName: "team1",
-				IsActive: false,
+			expectedUser:   inactiveUser,
+			expectedTxRuns: 1,
+			checkReport: func(t *testing.T, report *entity.ReassignmentReport) {
+				assert.Len(t, report.PullRequests, 7)
 			},
 		},
 	}
@@ -413,29 +210,38 @@ func TestUserService_ChangeActivateStatus(t *testing.T) {
 			userRepo := new(MockUserRepository)
 			prRepo := new(MockPullRequestRepository)
 			teamRepo := new(MockTeamRepository)
-			prService := NewPRService(prRepo, userRepo, teamRepo)
+			tx := &MockTransactor{}
+			prService := NewPRService(prRepo, userRepo, teamRepo, WithTransactor(tx))
 
-			tt.setupMocks(userRepo, prRepo, teamRepo, prService)
+			tt.setupMocks(userRepo, prRepo)
 
 			svc := NewUserService(userRepo, prRepo, teamRepo, prService)
 			ctx := t.Context()
 
-			user, err := svc.ChangeStatus(ctx, tt.userID, tt.isActive)
+			user, report, err := svc.ChangeStatus(ctx, tt.userID, tt.isActive)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError, err.Error())
 				assert.Nil(t, user)
+				assert.Nil(t, report)
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, user)
+				assert.NotNil(t, report)
+				assert.False(t, report.DryRun)
 
 				if tt.expectedUser != nil {
 					assert.Equal(t, tt.expectedUser.UserID, user.UserID)
 					assert.Equal(t, tt.expectedUser.IsActive, user.IsActive)
 				}
+
+				if tt.checkReport != nil {
+					tt.checkReport(t, report)
+				}
 			}
 
+			assert.Equal(t, tt.expectedTxRuns, tx.Calls)
 			userRepo.AssertExpectations(t)
 			prRepo.AssertExpectations(t)
 		})
@@ -554,3 +360,52 @@ func TestUserService_GetPRsAssignedTo(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_ReviewCapacity(t *testing.T) {
+	t.Run("capacity is reported with remaining slots", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limit := 2
+		userRepo.On("GetReviewCapacity", mock.Anything, "user1").
+			Return(entity.NewReviewCapacity(&limit, 3), nil)
+
+		svc := NewUserService(userRepo, new(MockPullRequestRepository), new(MockTeamRepository), nil)
+
+		capacity, err := svc.GetReviewCapacity(t.Context(), "user1")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, capacity.OpenReviews)
+		assert.Equal(t, 0, *capacity.Remaining)
+	})
+
+	t.Run("unlimited user has no remaining value", func(t *testing.T) {
+		capacity := entity.NewReviewCapacity(nil, 4)
+		assert.Nil(t, capacity.Remaining)
+	})
+
+	t.Run("set limit for unknown user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limit := 5
+		userRepo.On("SetMaxOpenReviews", mock.Anything, "ghost", &limit).
+			Return(errors.New("NOT_FOUND"))
+
+		svc := NewUserService(userRepo, new(MockPullRequestRepository), new(MockTeamRepository), nil)
+
+		user, err := svc.SetMaxOpenReviews(t.Context(), "ghost", &limit)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.Nil(t, user)
+	})
+
+	t.Run("set limit returns updated user", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limit := 8
+		userRepo.On("SetMaxOpenReviews", mock.Anything, "user1", &limit).Return(nil)
+		userRepo.On("GetUser", mock.Anything, "user1").
+			Return(&entity.User{UserID: "user1", MaxOpenReviews: &limit}, nil)
+
+		svc := NewUserService(userRepo, new(MockPullRequestRepository), new(MockTeamRepository), nil)
+
+		user, err := svc.SetMaxOpenReviews(t.Context(), "user1", &limit)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, *user.MaxOpenReviews)
+		userRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,254 @@
+// Package teamfile reads and writes the team roster files used for bulk
+// import. JSON and YAML hold {"teams": [...]} in the /team/add shape; CSV
+// holds one member per row and carries only the team lead among the
+// team-level settings.
+package teamfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+const (
+	colTeamName       = "team_name"
+	colUserID         = "user_id"
+	colUsername       = "username"
+	colIsActive       = "is_active"
+	colIsLead         = "is_lead"
+	colMaxOpenReviews = "max_open_reviews"
+	colTimeZone       = "time_zone"
+	colWorkStart      = "work_start"
+	colWorkEnd        = "work_end"
+)
+
+var ErrUnknownFormat = errors.New("unknown team file format")
+
+type document struct {
+	Teams []entity.Team `json:"teams"`
+}
+
+// ParseFormat accepts json, yaml/yml and csv.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// FormatFromPath picks the format from the file extension.
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(filepath.Ext(path))
+}
+
+// Parse decodes and validates a roster.
+func Parse(r io.Reader, format Format) ([]entity.Team, error) {
+	var (
+		teams []entity.Team
+		err   error
+	)
+
+	switch format {
+	case FormatJSON:
+		teams, err = parseJSON(r)
+	case FormatYAML:
+		teams, err = parseYAML(r)
+	case FormatCSV:
+		teams, err = parseCSV(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(teams); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func parseJSON(r io.Reader) ([]entity.Team, error) {
+	var doc document
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	return doc.Teams, nil
+}
+
+// parseYAML goes through JSON so the entity json tags define both formats.
+func parseYAML(r io.Reader) ([]entity.Team, error) {
+	var raw any
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode yaml: %w", err)
+	}
+
+	converted, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode yaml: %w", err)
+	}
+
+	return parseJSON(bytes.NewReader(converted))
+}
+
+//nolint:cyclop,funlen // one branch per optional column
+func parseCSV(r io.Reader) ([]entity.Team, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	for _, required := range []string{colTeamName, colUserID, colUsername} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv: missing column %q", required)
+		}
+	}
+
+	var teams []entity.Team
+	index := map[string]int{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		member := entity.TeamMember{
+			UserID:    get(colUserID),
+			Username:  get(colUsername),
+			TimeZone:  get(colTimeZone),
+			WorkStart: get(colWorkStart),
+			WorkEnd:   get(colWorkEnd),
+			IsActive:  true,
+		}
+
+		if v := get(colIsActive); v != "" {
+			if member.IsActive, err = strconv.ParseBool(v); err != nil {
+				return nil, fmt.Errorf("csv line %d: invalid %s %q", line, colIsActive, v)
+			}
+		}
+
+		if v := get(colMaxOpenReviews); v != "" {
+			limit, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("csv line %d: invalid %s %q", line, colMaxOpenReviews, v)
+			}
+
+			member.MaxOpenReviews = &limit
+		}
+
+		isLead := false
+		if v := get(colIsLead); v != "" {
+			if isLead, err = strconv.ParseBool(v); err != nil {
+				return nil, fmt.Errorf("csv line %d: invalid %s %q", line, colIsLead, v)
+			}
+		}
+
+		name := get(colTeamName)
+		i, ok := index[name]
+		if !ok {
+			i = len(teams)
+			index[name] = i
+			teams = append(teams, entity.Team{TeamName: name})
+		}
+
+		teams[i].Members = append(teams[i].Members, member)
+		if isLead {
+			if teams[i].LeadUserID != "" {
+				return nil, fmt.Errorf("csv line %d: team %q has more than one lead", line, name)
+			}
+
+			teams[i].LeadUserID = member.UserID
+		}
+	}
+
+	return teams, nil
+}
+
+func validate(teams []entity.Team) error {
+	seenTeams := map[string]bool{}
+	seenUsers := map[string]string{}
+
+	for _, team := range teams {
+		if team.TeamName == "" {
+			return errors.New("team_name is required")
+		}
+
+		if seenTeams[team.TeamName] {
+			return fmt.Errorf("team %q listed twice", team.TeamName)
+		}
+		seenTeams[team.TeamName] = true
+
+		lead := team.LeadUserID == ""
+		for _, member := range team.Members {
+			if member.UserID == "" || member.Username == "" {
+				return fmt.Errorf("team %q: user_id and username are required", team.TeamName)
+			}
+
+			if other, ok := seenUsers[member.UserID]; ok {
+				return fmt.Errorf("user %q listed in teams %q and %q", member.UserID, other, team.TeamName)
+			}
+			seenUsers[member.UserID] = team.TeamName
+
+			if member.UserID == team.LeadUserID {
+				lead = true
+			}
+		}
+
+		if !lead {
+			return fmt.Errorf("team %q: %w", team.TeamName, entity.ErrLeadNotMember)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package teamfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+func intPtr(v int) *int { return &v }
+
+func expectedTeams() []entity.Team {
+	return []entity.Team{
+		{
+			TeamName:   "backend",
+			LeadUserID: "u1",
+			Members: []entity.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true, MaxOpenReviews: intPtr(3)},
+				{UserID: "u2", Username: "Bob", IsActive: false, TimeZone: "Europe/Berlin",
+					WorkStart: "09:00", WorkEnd: "17:00"},
+			},
+		},
+		{
+			TeamName: "frontend",
+			Members:  []entity.TeamMember{{UserID: "u3", Username: "Carol", IsActive: true}},
+		},
+	}
+}
+
+func TestParse_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			input: `{"teams": [
+				{"team_name": "backend", "lead_user_id": "u1", "members": [
+					{"user_id": "u1", "username": "Alice", "is_active": true, "max_open_reviews": 3},
+					{"user_id": "u2", "username": "Bob", "is_active": false, "time_zone": "Europe/Berlin",
+					 "work_start": "09:00", "work_end": "17:00"}]},
+				{"team_name": "frontend", "members": [{"user_id": "u3", "username": "Carol", "is_active": true}]}
+			]}`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			input: `
+teams:
+  - team_name: backend
+    lead_user_id: u1
+    members:
+      - {user_id: u1, username: Alice, is_active: true, max_open_reviews: 3}
+      - user_id: u2
+        username: Bob
+        is_active: false
+        time_zone: Europe/Berlin
+        work_start: "09:00"
+        work_end: "17:00"
+  - team_name: frontend
+    members:
+      - {user_id: u3, username: Carol, is_active: true}
+`,
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			input: `team_name,user_id,username,is_active,is_lead,max_open_reviews,time_zone,work_start,work_end
+backend,u1,Alice,true,true,3,,,
+backend,u2,Bob,false,,,Europe/Berlin,09:00,17:00
+frontend,u3,Carol,,,,,,
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams, err := Parse(strings.NewReader(tt.input), tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, expectedTeams(), teams)
+		})
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+	}{
+		{"unknown json field", FormatJSON, `{"teams": [{"team_name": "a", "owner": "x"}]}`},
+		{"duplicate team", FormatJSON, `{"teams": [{"team_name": "a"}, {"team_name": "a"}]}`},
+		{"user in two teams", FormatCSV, "team_name,user_id,username\na,u1,A\nb,u1,A\n"},
+		{"missing column", FormatCSV, "team_name,user_id\na,u1\n"},
+		{"bad bool", FormatCSV, "team_name,user_id,username,is_active\na,u1,A,maybe\n"},
+		{"lead not member", FormatYAML, "teams:\n  - team_name: a\n    lead_user_id: u9\n    members: []\n"},
+		{"two leads", FormatCSV, "team_name,user_id,username,is_lead\na,u1,A,true\na,u2,B,true\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), tt.format)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	format, err := FormatFromPath("teams.YML")
+	require.NoError(t, err)
+	assert.Equal(t, FormatYAML, format)
+
+	_, err = FormatFromPath("teams.txt")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWrite_RoundTrips(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, expectedTeams()))
+
+			teams, err := Parse(&buf, format)
+			require.NoError(t, err)
+			assert.Equal(t, expectedTeams(), teams)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package teamfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.yaml.in/yaml/v3"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+)
+
+// ContentType is the media type served for the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatYAML:
+		return "application/yaml"
+	case FormatCSV:
+		return "text/csv"
+	default:
+		return "application/json"
+	}
+}
+
+// Write encodes teams so that Parse reads them back. CSV drops the team
+// settings other than the lead.
+func Write(w io.Writer, format Format, teams []entity.Team) error {
+	if teams == nil {
+		teams = []entity.Team{}
+	}
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(document{Teams: teams})
+	case FormatYAML:
+		return writeYAML(w, teams)
+	case FormatCSV:
+		return writeCSV(w, teams)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// writeYAML goes through JSON so the entity json tags name the keys.
+func writeYAML(w io.Writer, teams []entity.Team) error {
+	converted, err := json.Marshal(document{Teams: teams})
+	if err != nil {
+		return err
+	}
+
+	var raw any
+	if err := yaml.Unmarshal(converted, &raw); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(raw); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func writeCSV(w io.Writer, teams []entity.Team) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		colTeamName, colUserID, colUsername, colIsActive, colIsLead,
+		colMaxOpenReviews, colTimeZone, colWorkStart, colWorkEnd,
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		for _, member := range team.Members {
+			maxOpenReviews := ""
+			if member.MaxOpenReviews != nil {
+				maxOpenReviews = strconv.Itoa(*member.MaxOpenReviews)
+			}
+
+			if err := writer.Write([]string{
+				team.TeamName,
+				member.UserID,
+				member.Username,
+				strconv.FormatBool(member.IsActive),
+				strconv.FormatBool(member.UserID == team.LeadUserID),
+				maxOpenReviews,
+				member.TimeZone,
+				member.WorkStart,
+				member.WorkEnd,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS pull_requests
+    DROP COLUMN IF EXISTS number,
+    DROP COLUMN IF EXISTS repository_name;
+DROP TABLE IF EXISTS repositories;
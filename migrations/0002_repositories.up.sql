@@ -0,0 +1,14 @@
+CREATE TABLE repositories (
+                              repository_name TEXT PRIMARY KEY,
+                              vcs TEXT NOT NULL DEFAULT 'git',
+                              default_team TEXT NULL REFERENCES teams(team_name) ON DELETE SET NULL,
+                              created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN repository_name TEXT NULL REFERENCES repositories(repository_name) ON DELETE RESTRICT,
+    ADD COLUMN number INTEGER NULL,
+    ADD CONSTRAINT uq_pr_repository_number UNIQUE (repository_name, number),
+    ADD CONSTRAINT chk_pr_repository_number CHECK ((repository_name IS NULL) = (number IS NULL));
+
+CREATE INDEX idx_pr_repository ON pull_requests(repository_name);
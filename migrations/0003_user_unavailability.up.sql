@@ -0,0 +1,13 @@
+CREATE TABLE user_unavailability (
+                                     id BIGSERIAL PRIMARY KEY,
+                                     user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                     starts_at TIMESTAMPTZ NOT NULL,
+                                     ends_at TIMESTAMPTZ NOT NULL,
+                                     reason TEXT NOT NULL DEFAULT '',
+                                     handover BOOLEAN NOT NULL DEFAULT FALSE,
+                                     handed_over BOOLEAN NOT NULL DEFAULT FALSE,
+                                     CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id);
+CREATE INDEX idx_user_unavailability_period ON user_unavailability(starts_at, ends_at);
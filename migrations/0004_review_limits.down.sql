@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS max_open_reviews;
+ALTER TABLE IF EXISTS teams DROP COLUMN IF EXISTS default_max_open_reviews;
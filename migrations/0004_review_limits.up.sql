@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    ADD COLUMN default_max_open_reviews INTEGER NULL CHECK (default_max_open_reviews >= 0);
+
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
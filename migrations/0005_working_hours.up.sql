@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN work_start TIME NULL,
+    ADD COLUMN work_end TIME NULL,
+    ADD CONSTRAINT chk_users_working_hours CHECK ((work_start IS NULL) = (work_end IS NULL));
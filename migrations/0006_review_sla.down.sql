@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS pr_reviewers DROP COLUMN IF EXISTS overdue_flagged_at;
+ALTER TABLE IF EXISTS teams
+    DROP COLUMN IF EXISTS lead_user_id,
+    DROP COLUMN IF EXISTS review_sla_minutes;
@@ -0,0 +1,7 @@
+ALTER TABLE teams
+    ADD COLUMN review_sla_minutes INTEGER NULL CHECK (review_sla_minutes > 0),
+    ADD COLUMN lead_user_id TEXT NULL,
+    ADD CONSTRAINT fk_teams_lead FOREIGN KEY (lead_user_id) REFERENCES users(user_id) ON DELETE SET NULL;
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN overdue_flagged_at TIMESTAMPTZ NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE stats_snapshots (
+                                 snapshot_date DATE NOT NULL,
+                                 metric TEXT NOT NULL,
+                                 subject TEXT NOT NULL DEFAULT '',
+                                 value DOUBLE PRECISION NOT NULL,
+                                 PRIMARY KEY (snapshot_date, metric, subject)
+);
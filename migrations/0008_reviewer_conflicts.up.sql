@@ -0,0 +1,10 @@
+CREATE TABLE reviewer_conflicts (
+                                    user_a TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                    user_b TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                    reason TEXT NOT NULL DEFAULT '',
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    PRIMARY KEY (user_a, user_b),
+                                    CHECK (user_a < user_b)
+);
+
+CREATE INDEX idx_reviewer_conflicts_user_b ON reviewer_conflicts(user_b);
@@ -0,0 +1,13 @@
+CREATE TABLE assignment_decisions (
+                                      id BIGSERIAL PRIMARY KEY,
+                                      pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+                                      operation TEXT NOT NULL,
+                                      seed BIGINT NOT NULL,
+                                      candidates TEXT[] NOT NULL,
+                                      ranking TEXT[] NOT NULL,
+                                      picked TEXT[] NOT NULL,
+                                      pairings JSONB NOT NULL DEFAULT '{}',
+                                      decided_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_assignment_decisions_pr ON assignment_decisions(pull_request_id);
@@ -0,0 +1,9 @@
+// Package migrations embeds the versioned schema migrations applied by
+// pkg/migrate. Files are named NNNN_description.up.sql / .down.sql; add a new
+// pair with the next version instead of editing applied ones.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
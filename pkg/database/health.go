@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// PoolStats is the connection pool state reported by the readiness probe.
+type PoolStats struct {
+	TotalConns    int32 `json:"total_conns"`
+	IdleConns     int32 `json:"idle_conns"`
+	AcquiredConns int32 `json:"acquired_conns"`
+	MaxConns      int32 `json:"max_conns"`
+}
+
+// HealthCheck pings the database through the pool, which fails when no
+// connection can be acquired in time, and returns the pool stats.
+func (s *DatabaseSource) HealthCheck(ctx context.Context) (any, error) {
+	stat := s.Pool.Stat()
+	stats := PoolStats{
+		TotalConns:    stat.TotalConns(),
+		IdleConns:     stat.IdleConns(),
+		AcquiredConns: stat.AcquiredConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+
+	if err := s.Pool.Ping(ctx); err != nil {
+		return stats, fmt.Errorf("ping: %w", err)
+	}
+
+	return stats, nil
+}
@@ -1,6 +1,10 @@
 package database
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
 
 type Option func(source *DatabaseSource)
 
@@ -21,3 +25,9 @@ func SetMaxConnectTimeout(duration time.Duration) Option {
 		s.MaxConnectTimeout = duration
 	}
 }
+
+func SetTracer(tracer pgx.QueryTracer) Option {
+	return func(s *DatabaseSource) {
+		s.Tracer = tracer
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/util"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/config"
@@ -17,6 +18,7 @@ import (
 //nolint:revive // exported: DatabaseSource is a clear and descriptive name
 type DatabaseSource struct {
 	Pool               *pgxpool.Pool
+	Tracer             pgx.QueryTracer
 	MaxPoolSize        int
 	MaxConnectTimeout  time.Duration
 	MaxConnLifetime    time.Duration
@@ -78,6 +80,7 @@ func NewStorage(url string, options ...Option) (*DatabaseSource, error) {
 	)
 	cfg.HealthCheckPeriod = healthCheckPeriod
 	cfg.MaxConnIdleTime = maxConnIdleTime
+	cfg.ConnConfig.Tracer = src.Tracer
 
 	ctx := context.Background()
 
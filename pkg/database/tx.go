@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Querier is the part of pgxpool.Pool and pgx.Tx the repositories use, so
+// the same query runs on the pool or inside a transaction.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txKey struct{}
+
+// Querier returns the transaction started by WithinTx for ctx, or the pool
+// when there is none.
+func (s *DatabaseSource) Querier(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return s.Pool
+}
+
+// WithinTx runs fn in a transaction carried by the returned context, so every
+// repository call made with it joins the transaction. It commits when fn
+// returns nil and rolls back otherwise. A nested call opens a savepoint.
+func (s *DatabaseSource) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := s.Querier(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			return errors.Join(err, fmt.Errorf("rollback tx: %w", rbErr))
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
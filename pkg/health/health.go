@@ -0,0 +1,127 @@
+// Package health serves liveness and readiness probes. Readiness runs the
+// registered dependency checks and is forced off once shutdown begins so load
+// balancers stop routing before the server closes.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK    = "ok"
+	StatusReady = "ready"
+	StatusDown  = "not_ready"
+	StatusFail  = "fail"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+// Check reports a dependency's state; details are included in the readiness
+// response whether or not the check fails.
+type Check func(ctx context.Context) (details any, err error)
+
+type CheckResult struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type Checker struct {
+	mu           sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+	timeout      time.Duration
+}
+
+func NewChecker() *Checker {
+	return &Checker{timeout: defaultCheckTimeout}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// SetShuttingDown makes every following readiness probe fail.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready runs all checks concurrently, each bounded by the checker timeout.
+func (c *Checker) Ready(ctx context.Context) Report {
+	if c.shuttingDown.Load() {
+		return Report{Status: StatusDown, Checks: map[string]CheckResult{
+			"shutdown": {Status: StatusFail, Error: "server is shutting down"},
+		}}
+	}
+
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			details, err := nc.check(ctx)
+			results[i] = CheckResult{Status: StatusOK, Details: details}
+			if err != nil {
+				results[i].Status = StatusFail
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]CheckResult, len(checks))}
+	for i, nc := range checks {
+		report.Checks[nc.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// SchemaCheck fails when the database schema is older than the code expects.
+func SchemaCheck(current func(ctx context.Context) (int, error), expected int) Check {
+	return func(ctx context.Context) (any, error) {
+		version, err := current(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details := map[string]int{"current": version, "expected": expected}
+		if version < expected {
+			return details, fmt.Errorf("schema version %d, expected %d", version, expected)
+		}
+
+		return details, nil
+	}
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okCheck(context.Context) (any, error) { return map[string]int{"conns": 1}, nil }
+
+func failingCheck(context.Context) (any, error) { return nil, errors.New("down") }
+
+func serveReady(t *testing.T, c *Checker) (int, Report) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	c.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", http.NoBody))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+	return rec.Code, report
+}
+
+func TestReadyHandler(t *testing.T) {
+	t.Run("all checks pass", func(t *testing.T) {
+		c := NewChecker()
+		c.Add("database", okCheck)
+
+		code, report := serveReady(t, c)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, StatusReady, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+		assert.NotNil(t, report.Checks["database"].Details)
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		c := NewChecker()
+		c.Add("database", okCheck)
+		c.Add("schema", failingCheck)
+
+		code, report := serveReady(t, c)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, "down", report.Checks["schema"].Error)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		c := NewChecker()
+		c.Add("database", okCheck)
+		c.SetShuttingDown()
+
+		code, report := serveReady(t, c)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Contains(t, report.Checks, "shutdown")
+	})
+}
+
+func TestLiveHandler_IgnoresChecks(t *testing.T) {
+	c := NewChecker()
+	c.Add("database", failingCheck)
+
+	rec := httptest.NewRecorder()
+	c.LiveHandler(rec, httptest.NewRequest(http.MethodGet, "/health/live", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSchemaCheck(t *testing.T) {
+	version := func(v int) func(context.Context) (int, error) {
+		return func(context.Context) (int, error) { return v, nil }
+	}
+
+	_, err := SchemaCheck(version(2), 2)(context.Background())
+	assert.NoError(t, err)
+
+	_, err = SchemaCheck(version(1), 2)(context.Background())
+	assert.Error(t, err)
+}
+
+func TestJobs_Check(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	jobs := NewJobs()
+	jobs.now = func() time.Time { return now }
+
+	jobs.Register("sla_check", time.Minute)
+	jobs.Register("stats_snapshot", time.Hour)
+	jobs.Report("sla_check", nil)
+
+	_, err := jobs.Check(context.Background())
+	assert.NoError(t, err)
+
+	// failing runs keep the job healthy and show up in the details
+	for range 5 {
+		now = now.Add(time.Minute)
+		jobs.Report("sla_check", errors.New("pr-1: timeout"))
+	}
+
+	details, err := jobs.Check(context.Background())
+	assert.NoError(t, err)
+
+	status := details.(map[string]JobStatus)["sla_check"]
+	assert.True(t, status.Healthy)
+	assert.Equal(t, "pr-1: timeout", status.LastError)
+	assert.Equal(t, 5, status.ConsecutiveFailures)
+
+	// no run at all for more than three intervals: the loop has stopped
+	now = now.Add(4 * time.Minute)
+	details, err = jobs.Check(context.Background())
+	assert.EqualError(t, err, "jobs without a recent run: sla_check")
+	assert.False(t, details.(map[string]JobStatus)["sla_check"].Healthy)
+	assert.True(t, details.(map[string]JobStatus)["stats_snapshot"].Healthy)
+}
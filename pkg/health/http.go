@@ -0,0 +1,30 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// LiveHandler reports that the process is serving requests; it checks no
+// dependencies so a database outage does not get the pod restarted.
+func (c *Checker) LiveHandler(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// ReadyHandler answers 503 while any check fails or shutdown has begun.
+func (c *Checker) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready(r.Context())
+
+	status := http.StatusOK
+	if report.Status != StatusReady {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, report)
+}
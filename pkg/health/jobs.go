@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// staleAfter is how many intervals a job may go without finishing a run
+// before it is reported unhealthy, meaning its loop has stopped or hangs.
+// Failed runs only show up in the details: jobs such as the SLA check report
+// errors of single items, which must not take the instance out of rotation.
+const staleAfter = 3
+
+type JobStatus struct {
+	Interval            string     `json:"interval"`
+	LastRun             *time.Time `json:"last_run,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures,omitempty"`
+	Healthy             bool       `json:"healthy"`
+}
+
+type jobState struct {
+	interval    time.Duration
+	registered  time.Time
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastErr     error
+	failures    int
+}
+
+// Jobs tracks the background jobs' runs for the readiness probe.
+type Jobs struct {
+	mu   sync.Mutex
+	jobs map[string]*jobState
+	now  func() time.Time
+}
+
+func NewJobs() *Jobs {
+	return &Jobs{jobs: map[string]*jobState{}, now: time.Now}
+}
+
+func (j *Jobs) Register(name string, interval time.Duration) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.jobs[name] = &jobState{interval: interval, registered: j.now()}
+}
+
+// Report records the outcome of one run of a registered job.
+func (j *Jobs) Report(name string, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state, ok := j.jobs[name]
+	if !ok {
+		return
+	}
+
+	state.lastRun = j.now()
+	state.lastErr = err
+	if err == nil {
+		state.lastSuccess = state.lastRun
+		state.failures = 0
+	} else {
+		state.failures++
+	}
+}
+
+// Check fails when a job has not finished a run, successful or not, within
+// staleAfter intervals of its previous one, or of its registration if it
+// never ran.
+func (j *Jobs) Check(context.Context) (any, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	statuses := make(map[string]JobStatus, len(j.jobs))
+
+	var stale []string
+	for name, state := range j.jobs {
+		since := state.registered
+		if !state.lastRun.IsZero() {
+			since = state.lastRun
+		}
+
+		status := JobStatus{
+			Interval:            state.interval.String(),
+			ConsecutiveFailures: state.failures,
+			Healthy:             now.Sub(since) <= staleAfter*state.interval,
+		}
+		if !state.lastRun.IsZero() {
+			lastRun := state.lastRun
+			status.LastRun = &lastRun
+		}
+		if !state.lastSuccess.IsZero() {
+			lastSuccess := state.lastSuccess
+			status.LastSuccess = &lastSuccess
+		}
+		if state.lastErr != nil {
+			status.LastError = state.lastErr.Error()
+		}
+
+		if !status.Healthy {
+			stale = append(stale, name)
+		}
+
+		statuses[name] = status
+	}
+
+	if len(stale) > 0 {
+		sort.Strings(stale)
+
+		return statuses, fmt.Errorf("jobs without a recent run: %s", strings.Join(stale, ", "))
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	UserIDHeader    = "X-User-ID"
+)
+
+type loggerKey struct{}
+
+// WithContext stores a request-scoped logger in ctx.
+func WithContext(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// FromContext returns the logger stored by WithContext, falling back to
+// fallback and then to slog.Default.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if log, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return log
+	}
+
+	if fallback != nil {
+		return fallback
+	}
+
+	return slog.Default()
+}
+
+// Middleware assigns every request an ID (kept from X-Request-ID when the
+// caller sends one), stores a logger carrying it and the request's trace in
+// the request context and logs one line per completed request. It expects
+// chi's RequestID and the tracing middleware to run first.
+func Middleware(base *slog.Logger) func(http.Handler) http.Handler {
+	if base == nil {
+		base = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestID := middleware.GetReqID(r.Context())
+			w.Header().Set(RequestIDHeader, requestID)
+
+			// bound here so handlers logging without a context keep the trace
+			log := base.With("request_id", requestID).With(tracing.LogAttrs(r.Context())...)
+			ctx := WithContext(r.Context(), log)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+			}
+			if user := requestUser(r); user != "" {
+				attrs = append(attrs, slog.String("user", user))
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			log.LogAttrs(ctx, level, "request completed", attrs...)
+		})
+	}
+}
+
+// requestUser identifies the caller: the X-User-ID header when set, otherwise
+// the user_id query parameter most read endpoints take.
+func requestUser(r *http.Request) string {
+	if user := r.Header.Get(UserIDHeader); user != "" {
+		return user
+	}
+
+	return r.URL.Query().Get("user_id")
+}
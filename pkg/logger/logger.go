@@ -2,18 +2,41 @@ package logger
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
 )
 
+// Options configures the application logger.
+type Options struct {
+	Level  string
+	Format string
+	// DebugSampleEvery keeps one in every N debug records; 0 or 1 keeps all.
+	DebugSampleEvery uint64
+}
+
 type MultiLeveLHandler struct {
 	infoHandler  slog.Handler
 	debugHandler slog.Handler
 	errorHandler slog.Handler
+	level        slog.Leveler
 }
 
-func (MultiLeveLHandler) Enabled(context.Context, slog.Level) bool {
-	return true
+func (handler MultiLeveLHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if handler.level == nil {
+		return true
+	}
+
+	return level >= handler.level.Level()
 }
 
 //nolint:gocritic // hugeParam: slog.Record is part of standard interface
@@ -40,6 +63,7 @@ func (handler MultiLeveLHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		infoHandler:  handler.infoHandler.WithAttrs(attrs),
 		debugHandler: handler.debugHandler.WithAttrs(attrs),
 		errorHandler: handler.errorHandler.WithAttrs(attrs),
+		level:        handler.level,
 	}
 }
 
@@ -48,24 +72,64 @@ func (handler MultiLeveLHandler) WithGroup(name string) slog.Handler {
 		infoHandler:  handler.infoHandler.WithGroup(name),
 		debugHandler: handler.debugHandler.WithGroup(name),
 		errorHandler: handler.errorHandler.WithGroup(name),
+		level:        handler.level,
 	}
 }
 
+// SetupLogger returns the bootstrap logger used until the configuration is
+// loaded: JSON to stdout at info level.
 func SetupLogger() *slog.Logger {
-	handler := &MultiLeveLHandler{
-		infoHandler: slog.NewJSONHandler(
-			os.Stdout,
-			&slog.HandlerOptions{Level: slog.LevelInfo},
-		),
-		debugHandler: slog.NewJSONHandler(
-			os.Stdout,
-			&slog.HandlerOptions{Level: slog.LevelDebug},
-		),
-		errorHandler: slog.NewJSONHandler(
-			os.Stdout,
-			&slog.HandlerOptions{Level: slog.LevelError},
-		),
+	log, _ := New(os.Stdout, Options{})
+
+	return log
+}
+
+// New builds a logger writing to w according to opts.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	newHandler := func(min slog.Level) slog.Handler {
+		handlerOpts := &slog.HandlerOptions{Level: min}
+		if opts.Format == FormatText {
+			return slog.NewTextHandler(w, handlerOpts)
+		}
+
+		return slog.NewJSONHandler(w, handlerOpts)
+	}
+
+	switch opts.Format {
+	case FormatJSON, FormatText, "":
+	default:
+		return nil, fmt.Errorf("unknown log format %q", opts.Format)
+	}
+
+	var handler slog.Handler = &MultiLeveLHandler{
+		infoHandler:  newHandler(slog.LevelInfo),
+		debugHandler: newHandler(slog.LevelDebug),
+		errorHandler: newHandler(slog.LevelError),
+		level:        level,
+	}
+
+	if opts.DebugSampleEvery > 1 {
+		handler = NewSamplingHandler(handler, opts.DebugSampleEvery)
+	}
+
+	return slog.New(tracing.NewLogHandler(handler)), nil
+}
+
+// ParseLevel accepts debug, info, warn and error; empty means info.
+func ParseLevel(s string) (slog.Level, error) {
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(s))); err != nil {
+		return level, fmt.Errorf("unknown log level %q", s)
 	}
 
-	return slog.New(handler)
+	return level, nil
 }
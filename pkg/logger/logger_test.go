@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNew_FiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, Options{Level: "warn"})
+	require.NoError(t, err)
+
+	log.Debug("debug")
+	log.Info("info")
+	log.Warn("warn")
+	log.Error("error")
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "warn", records[0]["msg"])
+	assert.Equal(t, "error", records[1]["msg"])
+}
+
+func TestNew_RejectsUnknownSettings(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, Options{Level: "loud"})
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, Options{Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestNew_SamplesDebugOnly(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, Options{Level: "debug", DebugSampleEvery: 3})
+	require.NoError(t, err)
+
+	for range 6 {
+		log.Debug("noisy")
+		log.With("k", "v").Info("kept")
+	}
+
+	debug, info := 0, 0
+	for _, record := range decodeLines(t, &buf) {
+		switch record["level"] {
+		case "DEBUG":
+			debug++
+		case "INFO":
+			info++
+		}
+	}
+
+	assert.Equal(t, 2, debug)
+	assert.Equal(t, 6, info)
+}
+
+func TestMiddleware_LogsRequestWithContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base, err := New(&buf, Options{})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID, Middleware(base))
+	r.Get("/users/getReview", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context(), nil).Info("inside handler")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", http.NoBody)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "req-42", rec.Header().Get(RequestIDHeader))
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "inside handler", records[0]["msg"])
+	assert.Equal(t, "req-42", records[0]["request_id"])
+
+	completed := records[1]
+	assert.Equal(t, "request completed", completed["msg"])
+	assert.Equal(t, "req-42", completed["request_id"])
+	assert.Equal(t, http.MethodGet, completed["method"])
+	assert.Equal(t, "/users/getReview", completed["route"])
+	assert.Equal(t, "u1", completed["user"])
+	assert.InDelta(t, http.StatusNotFound, completed["status"], 0)
+	assert.Contains(t, completed, "latency")
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// SamplingHandler passes one in every n debug records; other levels are never
+// dropped. Derived handlers share the counter so With() does not reset it.
+type SamplingHandler struct {
+	slog.Handler
+	every   uint64
+	counter *atomic.Uint64
+}
+
+func NewSamplingHandler(h slog.Handler, every uint64) *SamplingHandler {
+	return &SamplingHandler{Handler: h, every: every, counter: &atomic.Uint64{}}
+}
+
+//nolint:gocritic // hugeParam: slog.Record is part of standard interface
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level == slog.LevelDebug && h.counter.Add(1)%h.every != 1 {
+		return nil
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithAttrs(attrs), every: h.every, counter: h.counter}
+}
+
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithGroup(name), every: h.every, counter: h.counter}
+}
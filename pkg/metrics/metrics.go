@@ -0,0 +1,61 @@
+// Package metrics holds the Prometheus registry of the service and the
+// instrumentation shared by the HTTP, service and database layers. Every
+// label here has a bounded set of values.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	OutcomeAssigned    = "assigned"
+	OutcomeNoCandidate = "no_candidate"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by route pattern and status code",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	DBQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries by statement kind",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		},
+		[]string{"statement", "status"},
+	)
+
+	AssignmentOutcomes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reviewer_assignments_total",
+			Help: "Reviewer selections by operation and outcome",
+		},
+		[]string{"operation", "outcome"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(
+		HTTPRequestDuration,
+		DBQueryDuration,
+		AssignmentOutcomes,
+	)
+}
+
+// ObserveAssignment counts one reviewer selection for the operation.
+func ObserveAssignment(operation string, assigned int) {
+	outcome := OutcomeAssigned
+	if assigned == 0 {
+		outcome = OutcomeNoCandidate
+	}
+
+	AssignmentOutcomes.WithLabelValues(operation, outcome).Inc()
+}
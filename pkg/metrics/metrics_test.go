@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_LabelsByRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/team/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, path := range []string{"/team/a", "/team/b", "/random/path"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	}
+
+	out, err := testutil.CollectAndFormat(HTTPRequestDuration, expfmt.TypeTextPlain,
+		"http_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Contains(t, string(out),
+		`http_request_duration_seconds_count{method="GET",route="/team/{name}",status="418"} 2`)
+	assert.Contains(t, string(out),
+		`http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.Equal(t, 2, testutil.CollectAndCount(HTTPRequestDuration))
+}
+
+func TestStatementKind(t *testing.T) {
+	assert.Equal(t, "select", statementKind("\n\t SELECT 1"))
+	assert.Equal(t, "with", statementKind("WITH x AS (SELECT 1) SELECT * FROM x"))
+	assert.Equal(t, "other", statementKind("VACUUM"))
+	assert.Equal(t, "other", statementKind(""))
+}
+
+func TestObserveAssignment(t *testing.T) {
+	ObserveAssignment("create", 2)
+	ObserveAssignment("create", 0)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(AssignmentOutcomes.WithLabelValues("create", OutcomeAssigned)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(AssignmentOutcomes.WithLabelValues("create", OutcomeNoCandidate)), 0)
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	at        time.Time
+	statement string
+}
+
+// QueryTracer is a pgx tracer observing query durations.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{
+		at:        time.Now(),
+		statement: statementKind(data.SQL),
+	})
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	status := "ok"
+	if data.Err != nil {
+		status = "error"
+	}
+
+	DBQueryDuration.WithLabelValues(start.statement, status).Observe(time.Since(start.at).Seconds())
+}
+
+// statementKind reduces SQL to its leading keyword to keep the label bounded.
+func statementKind(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "other"
+	}
+
+	switch kind := strings.ToLower(fields[0]); kind {
+	case "select", "insert", "update", "delete", "with", "begin", "commit", "rollback":
+		return kind
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,133 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/migrations"
+)
+
+// testdata/legacy_init.sql is the schema part of the init.sql deployments
+// were created with before migrations existed.
+const legacyInitFile = "testdata/legacy_init.sql"
+
+// testDatabaseEnv names a Postgres URL for tests that need a real database;
+// they are skipped without it.
+const testDatabaseEnv = "MIGRATE_TEST_DATABASE_URL"
+
+func TestEmbeddedMigrations_InitIsLegacySchema(t *testing.T) {
+	legacy, err := os.ReadFile(legacyInitFile)
+	require.NoError(t, err)
+
+	loaded, err := Load(migrations.FS)
+	require.NoError(t, err)
+
+	// databases created by the old init.sql are stamped with version 1, so it
+	// must not contain anything the old script did not create
+	assert.Equal(t, strings.TrimSpace(string(legacy)), strings.TrimSpace(loaded[0].Up))
+}
+
+func TestMigrator_UpgradesLegacyDatabase(t *testing.T) {
+	url := os.Getenv(testDatabaseEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	ctx := t.Context()
+	pool := legacySchemaPool(t, url)
+
+	legacy, err := os.ReadFile(legacyInitFile)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, string(legacy))
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO teams (team_name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name) VALUES ('u1', 'Alice', 'backend'), ('u2', 'Bob', 'backend');
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('pr-1', 'Old', 'u1');
+		INSERT INTO pr_reviewers (pull_request_id, reviewer_id) VALUES ('pr-1', 'u2');`)
+	require.NoError(t, err)
+
+	m, err := New(pool, migrations.FS, WithBaseline(1, "teams"))
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	want := make([]int, 0, m.Latest()-1)
+	for version := 2; version <= m.Latest(); version++ {
+		want = append(want, version)
+	}
+
+	assert.Equal(t, want, applied, "everything after the legacy schema is applied")
+
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, m.Latest(), version)
+
+	for _, column := range []string{
+		"pull_requests.repository_name",
+		"users.max_open_reviews",
+		"users.time_zone",
+		"teams.lead_user_id",
+		"pr_reviewers.overdue_flagged_at",
+		"user_unavailability.handover",
+		"stats_snapshots.value",
+	} {
+		table, name, _ := strings.Cut(column, ".")
+
+		var exists bool
+		require.NoError(t, pool.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+			)`, table, name).Scan(&exists))
+		assert.True(t, exists, "%s is missing after the upgrade", column)
+	}
+
+	var timeZone string
+	require.NoError(t, pool.QueryRow(ctx, `SELECT time_zone FROM users WHERE user_id = 'u2'`).Scan(&timeZone))
+	assert.Equal(t, "UTC", timeZone, "existing rows get column defaults")
+
+	reverted, err := m.Down(ctx, m.Latest())
+	require.NoError(t, err)
+	assert.Len(t, reverted, m.Latest())
+}
+
+// legacySchemaPool connects to url with a fresh schema first on the search
+// path, dropped when the test ends.
+func legacySchemaPool(t *testing.T, url string) *pgxpool.Pool {
+	t.Helper()
+
+	ctx := t.Context()
+	schema := fmt.Sprintf("migrate_legacy_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(admin.Close)
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return pool
+}
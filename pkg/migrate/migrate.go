@@ -0,0 +1,260 @@
+// Package migrate applies versioned schema migrations, recording them in the
+// schema_migrations table. A Postgres advisory lock serialises instances
+// starting at the same time, so each migration runs exactly once.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID identifies the advisory lock; any constant shared by all instances
+// works.
+const lockID int64 = 0x5e7a_11ce
+
+const createTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`
+
+var ErrNothingToRevert = errors.New("no applied migrations to revert")
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	// baseline marks databases created before migrations existed.
+	baselineVersion int
+	baselineTable   string
+}
+
+type Option func(m *Migrator)
+
+// WithBaseline records migrations up to version as applied, without running
+// them, when schema_migrations is empty but table already exists — i.e. the
+// schema was created by the old init script.
+func WithBaseline(version int, table string) Option {
+	return func(m *Migrator) {
+		m.baselineVersion = version
+		m.baselineTable = table
+	}
+}
+
+func New(pool *pgxpool.Pool, fsys fs.FS, options ...Option) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{pool: pool, migrations: migrations}
+	for _, option := range options {
+		option(m)
+	}
+
+	return m, nil
+}
+
+// Latest is the version the embedded migrations bring the schema to.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the highest applied version, 0 for an empty database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var version int
+
+	err := m.pool.QueryRow(ctx, `
+		SELECT COALESCE(MAX(version), 0)
+		FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, nil
+}
+
+// Up applies all pending migrations and returns their versions.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	var applied []int
+
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		if err := m.baseline(ctx, conn); err != nil {
+			return err
+		}
+
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if done[migration.Version] {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration.Up, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, `
+					INSERT INTO schema_migrations (version, name)
+					VALUES ($1, $2)`, migration.Version, migration.Name)
+
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration.Version)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	var reverted []int
+
+	err := m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if !done[migration.Version] {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration.Down, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, `
+					DELETE FROM schema_migrations
+					WHERE version = $1`, migration.Version)
+
+				return err
+			}); err != nil {
+				return fmt.Errorf("revert %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = append(reverted, migration.Version)
+		}
+
+		if len(reverted) == 0 {
+			return ErrNothingToRevert
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// locked runs fn on one connection holding the advisory lock; other
+// instances block in pg_advisory_lock until it is released.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn) error) (err error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// a fresh context so a cancelled ctx still releases the lock
+		_, unlockErr := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+		if unlockErr != nil && err == nil {
+			err = fmt.Errorf("release migration lock: %w", unlockErr)
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, createTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) baseline(ctx context.Context, conn *pgxpool.Conn) error {
+	if m.baselineVersion == 0 {
+		return nil
+	}
+
+	var empty, exists bool
+
+	err := conn.QueryRow(ctx, `
+		SELECT NOT EXISTS (SELECT 1 FROM schema_migrations),
+		       to_regclass($1) IS NOT NULL`, m.baselineTable).Scan(&empty, &exists)
+	if err != nil {
+		return fmt.Errorf("check baseline: %w", err)
+	}
+
+	if !empty || !exists {
+		return nil
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > m.baselineVersion {
+			break
+		}
+
+		if _, err := conn.Exec(ctx, `
+			INSERT INTO schema_migrations (version, name)
+			VALUES ($1, $2)`, migration.Version, migration.Name); err != nil {
+			return fmt.Errorf("record baseline %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]bool, error) {
+	rows, err := conn.Query(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read applied migrations: %w", err)
+	}
+
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("read applied migrations: %w", err)
+	}
+
+	done := make(map[int]bool, len(versions))
+	for _, version := range versions {
+		done[version] = true
+	}
+
+	return done, nil
+}
+
+// apply runs a migration script and its bookkeeping in one transaction.
+func apply(ctx context.Context, conn *pgxpool.Conn, script string, record func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck // rollback after commit is a no-op
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+
+	if err := record(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
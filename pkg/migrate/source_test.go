@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/migrations"
+)
+
+func TestLoad_SortsPairs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX i ON t(c);")},
+		"0002_add_index.down.sql": {Data: []byte("DROP INDEX i;")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE t (c INT);")},
+		"0001_init.down.sql":      {Data: []byte("DROP TABLE t;")},
+		"README.md":               {Data: []byte("ignored")},
+	}
+
+	loaded, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, Migration{
+		Version: 1, Name: "init", Up: "CREATE TABLE t (c INT);", Down: "DROP TABLE t;",
+	}, loaded[0])
+	assert.Equal(t, 2, loaded[1].Version)
+	assert.Equal(t, "add_index", loaded[1].Name)
+}
+
+func TestLoad_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{"0001_init.up.sql": {Data: []byte("SELECT 1")}},
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":    {Data: []byte("SELECT 1")},
+				"0001_init.down.sql":  {Data: []byte("SELECT 1")},
+				"0001_other.up.sql":   {Data: []byte("SELECT 1")},
+				"0001_other.down.sql": {Data: []byte("SELECT 1")},
+			},
+		},
+		{
+			name: "zero version",
+			fsys: fstest.MapFS{
+				"0000_init.up.sql":   {Data: []byte("SELECT 1")},
+				"0000_init.down.sql": {Data: []byte("SELECT 1")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for i, m := range loaded {
+		assert.Equal(t, i+1, m.Version, "migration versions must be contiguous")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -28,6 +29,25 @@ type Server struct {
 	internalServer  *http.Server
 	channelErr      chan error
 	shutdownTimeout time.Duration
+	// drainDelay keeps serving after the shutdown hooks ran so load
+	// balancers can notice the failing readiness probe.
+	drainDelay    time.Duration
+	shutdownHooks []func()
+	drainOnce     sync.Once
+}
+
+// drain runs the shutdown hooks once and waits for the drain delay.
+func (s *Server) drain(logger *slog.Logger) {
+	s.drainOnce.Do(func() {
+		for _, hook := range s.shutdownHooks {
+			hook()
+		}
+
+		if s.drainDelay > 0 {
+			logger.Info("draining traffic before shutdown", "delay", s.drainDelay.String())
+			time.Sleep(s.drainDelay)
+		}
+	})
 }
 
 func (s *Server) Start() {
@@ -61,6 +81,7 @@ func (s *Server) FullShutdownTimeout(logger *slog.Logger) error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 	logger.Info("Shutting down server...\n")
+	s.drain(logger)
 
 	if err := s.internalServer.Shutdown(ctx); err != nil {
 		return fmt.Errorf("server shutdown filed: %w", err)
@@ -84,6 +105,8 @@ func (s *Server) GracefulShutdown(logger *slog.Logger) {
 		logger.Info("shutdown timeout reached")
 	}
 
+	s.drain(logger)
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
@@ -102,12 +125,16 @@ func StartServer(
 	cfg *config.Config,
 	controller *chi.Mux,
 	logger *slog.Logger,
+	options ...Option,
 ) *Server {
 	customServer := NewServer(controller,
-		SetReadTimeout(*cfg.Server.ReadTimeout),
-		SetWriteTimeout(*cfg.Server.WriteTimeout),
-		SetAddr(cfg.Server.Addr),
-		SetShutdownTimeout(cfg.Server.ShutdownTimeout),
+		append([]Option{
+			SetReadTimeout(*cfg.Server.ReadTimeout),
+			SetWriteTimeout(*cfg.Server.WriteTimeout),
+			SetAddr(cfg.Server.Addr),
+			SetShutdownTimeout(cfg.Server.ShutdownTimeout),
+			SetDrainDelay(cfg.Server.DrainDelay),
+		}, options...)...,
 	)
 	logger.Info("server shutdown info",
 		"shutdownTimeout", fmt.Sprintf("%d %s",
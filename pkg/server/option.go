@@ -34,3 +34,17 @@ func SetShutdownTimeout(duration time.Duration) Option {
 		srv.shutdownTimeout = duration
 	}
 }
+
+func SetDrainDelay(duration time.Duration) Option {
+	return func(srv *Server) {
+		srv.drainDelay = duration
+	}
+}
+
+// OnShutdown registers a hook run when shutdown begins, before the drain
+// delay and before connections are closed.
+func OnShutdown(hook func()) Option {
+	return func(srv *Server) {
+		srv.shutdownHooks = append(srv.shutdownHooks, hook)
+	}
+}
@@ -2,28 +2,81 @@ package server
 
 import (
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/health"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/logger"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/metrics"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 func RegisterRoutes(h *handlers.Services, r *chi.Mux) {
+	r.Use(
+		middleware.RequestID,
+		tracing.Middleware,
+		logger.Middleware(h.Log),
+		metrics.Middleware,
+	)
+
 	r.Route("/team", func(r chi.Router) {
 		r.Post("/add", h.TeamAddHandler)
 		r.Get("/get", h.TeamGetHandler)
+		r.Post("/setReviewSLA", h.TeamSetReviewSLAHandler)
+	})
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Post("/import", h.AdminImportHandler)
+		r.Get("/export", h.AdminExportHandler)
+		r.Post("/conflicts/add", h.ConflictAddHandler)
+		r.Post("/conflicts/remove", h.ConflictRemoveHandler)
+		r.Get("/conflicts/list", h.ConflictListHandler)
 	})
 
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/setIsActive", h.UserSetIsActiveHandler)
 		r.Get("/getReview", h.UserGetReviewHandler)
 		r.Post("/deactivate", h.UsersMassDeactivateHandler)
+		r.Post("/bulkSetIsActive", h.UsersBulkSetIsActiveHandler)
+		r.Post("/handover", h.UsersHandoverHandler)
+		r.Post("/setMaxOpenReviews", h.UserSetMaxOpenReviewsHandler)
+		r.Post("/setWorkingHours", h.UserSetWorkingHoursHandler)
+		r.Post("/unavailability/add", h.UnavailabilityAddHandler)
+		r.Get("/unavailability/list", h.UnavailabilityListHandler)
+		r.Post("/unavailability/delete", h.UnavailabilityDeleteHandler)
 	})
 
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", h.PRCreateHandler)
 		r.Post("/merge", h.PRMergeHandler)
 		r.Post("/reassign", h.PRReassignHandler)
+		r.Post("/reviewers/add", h.PRAddReviewerHandler)
+		r.Post("/reviewers/remove", h.PRRemoveReviewerHandler)
+		r.Get("/explain", h.PRExplainHandler)
+	})
+
+	r.Route("/repository", func(r chi.Router) {
+		r.Post("/add", h.RepositoryAddHandler)
+		r.Get("/get", h.RepositoryGetHandler)
+		r.Get("/pullRequests", h.RepositoryPRListHandler)
+	})
+
+	r.Route("/stats", func(r chi.Router) {
+		r.Get("/overdue", h.OverdueReviewsHandler)
+		r.Get("/turnaround", h.TurnaroundHandler)
+		r.Get("/history", h.StatsHistoryHandler)
+		r.Get("/fairness", h.FairnessHandler)
 	})
 
 	r.Get("/metrics", h.MetricsHandler)
 	r.Get("/loadtest", h.LoadTestHandler)
 }
+
+// RegisterHealthRoutes mounts the probes; call it after RegisterRoutes, which
+// installs the middlewares.
+func RegisterHealthRoutes(c *health.Checker, r *chi.Mux) {
+	r.Route("/health", func(r chi.Router) {
+		r.Get("/live", c.LiveHandler)
+		r.Get("/ready", c.ReadyHandler)
+	})
+}
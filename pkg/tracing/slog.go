@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// LogHandler adds trace_id and span_id to records logged with a context that
+// carries a recording span, unless the logger already has them bound.
+type LogHandler struct {
+	slog.Handler
+	bound bool
+}
+
+func NewLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{Handler: h}
+}
+
+// LogAttrs returns the trace_id and span_id of the span in ctx for binding
+// to a logger with With, so records logged without a context carry them too.
+func LogAttrs(ctx context.Context) []any {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []any{
+		slog.String(traceIDKey, sc.TraceID().String()),
+		slog.String(spanIDKey, sc.SpanID().String()),
+	}
+}
+
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && !h.bound {
+		r.AddAttrs(
+			slog.String(traceIDKey, sc.TraceID().String()),
+			slog.String(spanIDKey, sc.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	bound := h.bound
+	for _, attr := range attrs {
+		if attr.Key == traceIDKey {
+			bound = true
+		}
+	}
+
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs), bound: bound}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name), bound: h.bound}
+}
@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) Import(
+	ctx context.Context,
+	teams []entity.Team,
+	dryRun bool,
+) (*entity.ImportReport, error) {
+	args := m.Called(ctx, teams, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	report, ok := args.Get(0).(*entity.ImportReport)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return report, args.Error(1)
+}
+
+func (m *MockImportService) Export(ctx context.Context) ([]entity.Team, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	teams, ok := args.Get(0).([]entity.Team)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return teams, args.Error(1)
+}
+
+func TestServices_AdminImportHandler(t *testing.T) {
+	parsed := []entity.Team{{
+		TeamName: "backend",
+		Members:  []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}}
+
+	t.Run("defaults to dry run", func(t *testing.T) {
+		importService := new(MockImportService)
+		importService.On("Import", mock.Anything, parsed, true).
+			Return(&entity.ImportReport{DryRun: true}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), ImportService: importService}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/import",
+			strings.NewReader("teams:\n  - team_name: backend\n    members:\n"+
+				"      - {user_id: u1, username: Alice, is_active: true}\n"))
+		req.Header.Set("Content-Type", "application/yaml")
+		w := httptest.NewRecorder()
+
+		services.AdminImportHandler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"dry_run":true`)
+		importService.AssertExpectations(t)
+	})
+
+	t.Run("applies csv when dry_run=false", func(t *testing.T) {
+		importService := new(MockImportService)
+		importService.On("Import", mock.Anything, parsed, false).
+			Return(&entity.ImportReport{Applied: true}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), ImportService: importService}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/import?format=csv&dry_run=false",
+			strings.NewReader("team_name,user_id,username\nbackend,u1,Alice\n"))
+		w := httptest.NewRecorder()
+
+		services.AdminImportHandler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"applied":true`)
+		importService.AssertExpectations(t)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		importService := new(MockImportService)
+		services := &handlers.Services{Log: newTestLogger(), ImportService: importService}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/import",
+			strings.NewReader(`{"teams": [{"team_name": ""}]}`))
+		w := httptest.NewRecorder()
+
+		services.AdminImportHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		importService.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid dry_run", func(t *testing.T) {
+		services := &handlers.Services{Log: newTestLogger(), ImportService: new(MockImportService)}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/import?dry_run=maybe", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		services.AdminImportHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServices_AdminExportHandler(t *testing.T) {
+	importService := new(MockImportService)
+	importService.On("Export", mock.Anything).Return([]entity.Team{{
+		TeamName:   "backend",
+		LeadUserID: "u1",
+		Members:    []entity.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}}, nil)
+
+	services := &handlers.Services{Log: newTestLogger(), ImportService: importService}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/export?format=csv", http.NoBody)
+	w := httptest.NewRecorder()
+
+	services.AdminExportHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "backend,u1,Alice,true,true,,,,")
+}
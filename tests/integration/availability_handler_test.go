@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockAvailabilityService struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityService) AddUnavailability(
+	ctx context.Context,
+	window *entity.Unavailability,
+) (*entity.Unavailability, error) {
+	args := m.Called(ctx, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).(*entity.Unavailability)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockAvailabilityService) ListUnavailability(
+	ctx context.Context,
+	userID string,
+) ([]*entity.Unavailability, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).([]*entity.Unavailability)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockAvailabilityService) DeleteUnavailability(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityService) ProcessStartedWindows(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestServices_UnavailabilityAddHandler(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		requestBody    interface{}
+		setupMocks     func(*MockAvailabilityService)
+		name           string
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			requestBody: entity.Unavailability{
+				UserID:   "u1",
+				StartsAt: start,
+				EndsAt:   start.Add(7 * 24 * time.Hour),
+				Reason:   "vacation",
+				Handover: true,
+			},
+			setupMocks: func(svc *MockAvailabilityService) {
+				svc.On("AddUnavailability", mock.Anything, mock.AnythingOfType("*entity.Unavailability")).
+					Return(&entity.Unavailability{ID: 1, UserID: "u1", StartsAt: start}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "ends before start",
+			requestBody: entity.Unavailability{
+				UserID:   "u1",
+				StartsAt: start,
+				EndsAt:   start.Add(-time.Hour),
+			},
+			setupMocks:     func(_ *MockAvailabilityService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "user not found",
+			requestBody: entity.Unavailability{
+				UserID:   "ghost",
+				StartsAt: start,
+				EndsAt:   start.Add(time.Hour),
+			},
+			setupMocks: func(svc *MockAvailabilityService) {
+				svc.On("AddUnavailability", mock.Anything, mock.Anything).Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockAvailabilityService)
+			tt.setupMocks(svc)
+
+			services := &handlers.Services{Log: newTestLogger(), AvailabilityService: svc}
+
+			b, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/unavailability/add", bytes.NewBuffer(b))
+			w := httptest.NewRecorder()
+
+			services.UnavailabilityAddHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServices_UnavailabilityDeleteHandler(t *testing.T) {
+	svc := new(MockAvailabilityService)
+	svc.On("DeleteUnavailability", mock.Anything, int64(5)).Return(entity.ErrNotFound)
+
+	services := &handlers.Services{Log: newTestLogger(), AvailabilityService: svc}
+
+	b, err := json.Marshal(handlers.UnavailabilityDeleteRequest{ID: 5})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/unavailability/delete", bytes.NewBuffer(b))
+	w := httptest.NewRecorder()
+
+	services.UnavailabilityDeleteHandler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	svc.AssertExpectations(t)
+}
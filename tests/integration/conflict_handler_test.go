@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockConflictService struct {
+	mock.Mock
+}
+
+func (m *MockConflictService) AddConflict(
+	ctx context.Context,
+	conflict *entity.ReviewerConflict,
+) (*entity.ReviewerConflict, error) {
+	args := m.Called(ctx, conflict)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).(*entity.ReviewerConflict)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockConflictService) DeleteConflict(ctx context.Context, userID, otherUserID string) error {
+	args := m.Called(ctx, userID, otherUserID)
+	return args.Error(0)
+}
+
+func (m *MockConflictService) ListConflicts(
+	ctx context.Context,
+	userID string,
+) ([]*entity.ReviewerConflict, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).([]*entity.ReviewerConflict)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func TestServices_ConflictAddHandler(t *testing.T) {
+	tests := []struct {
+		requestBody    interface{}
+		setupMocks     func(*MockConflictService)
+		name           string
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: handlers.ConflictRequest{UserID: "u1", OtherUserID: "u2", Reason: "manager"},
+			setupMocks: func(svc *MockConflictService) {
+				svc.On("AddConflict", mock.Anything, &entity.ReviewerConflict{
+					UserID:      "u1",
+					OtherUserID: "u2",
+					Reason:      "manager",
+				}).Return(&entity.ReviewerConflict{UserID: "u1", OtherUserID: "u2", Reason: "manager"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "same user twice",
+			requestBody:    handlers.ConflictRequest{UserID: "u1", OtherUserID: "u1"},
+			setupMocks:     func(_ *MockConflictService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing other user",
+			requestBody:    handlers.ConflictRequest{UserID: "u1"},
+			setupMocks:     func(_ *MockConflictService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "user not found",
+			requestBody: handlers.ConflictRequest{UserID: "u1", OtherUserID: "ghost"},
+			setupMocks: func(svc *MockConflictService) {
+				svc.On("AddConflict", mock.Anything, mock.Anything).Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockConflictService)
+			tt.setupMocks(svc)
+
+			services := &handlers.Services{Log: newTestLogger(), ConflictService: svc}
+
+			b, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/conflicts/add", bytes.NewBuffer(b))
+			w := httptest.NewRecorder()
+
+			services.ConflictAddHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServices_ConflictRemoveHandler(t *testing.T) {
+	svc := new(MockConflictService)
+	svc.On("DeleteConflict", mock.Anything, "u2", "u1").Return(entity.ErrNotFound)
+
+	services := &handlers.Services{Log: newTestLogger(), ConflictService: svc}
+
+	b, err := json.Marshal(handlers.ConflictRequest{UserID: "u2", OtherUserID: "u1"})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/conflicts/remove", bytes.NewBuffer(b))
+	w := httptest.NewRecorder()
+
+	services.ConflictRemoveHandler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestServices_ConflictListHandler(t *testing.T) {
+	svc := new(MockConflictService)
+	svc.On("ListConflicts", mock.Anything, "u1").Return([]*entity.ReviewerConflict{
+		{UserID: "u1", OtherUserID: "u2", Reason: "manager"},
+	}, nil)
+
+	services := &handlers.Services{Log: newTestLogger(), ConflictService: svc}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/conflicts/list?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	services.ConflictListHandler(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp handlers.ConflictListResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp.UserID)
+	require.Len(t, resp.Conflicts, 1)
+	assert.Equal(t, "u2", resp.Conflicts[0].OtherUserID)
+}
@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+func plannedReport() *entity.ReassignmentReport {
+	report := entity.NewReassignmentReport()
+	report.DryRun = true
+	report.DeactivatedUserIDs = []string{"u1"}
+	report.Add(&entity.PRReassignment{
+		PullRequestID: "pr-1",
+		Removed:       []string{"u1"},
+		Added:         []string{},
+		Reviewers:     []string{},
+		Understaffed:  true,
+	})
+
+	return report
+}
+
+func TestServices_DryRun(t *testing.T) {
+	t.Run("setIsActive plans the deactivation", func(t *testing.T) {
+		userService := new(MockUserService)
+		userService.On("PlanStatusChange", mock.Anything, "u1", false).
+			Return(&entity.User{UserID: "u1", IsActive: false}, plannedReport(), nil)
+
+		services := &handlers.Services{Log: newTestLogger(), UserService: userService}
+
+		b, _ := json.Marshal(handlers.UserSetIsActiveRequest{UserID: "u1", IsActive: false})
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive?dry_run=true", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+
+		services.UserSetIsActiveHandler(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp handlers.UserSetIsActiveResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Reassignment)
+		assert.True(t, resp.Reassignment.DryRun)
+		assert.Equal(t, []string{"pr-1"}, resp.Reassignment.WithoutReviewers)
+		userService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("deactivate plans the mass deactivation", func(t *testing.T) {
+		userService := new(MockUserService)
+		userService.On("PlanMassDeactivate", mock.Anything, []entity.User{{UserID: "u1"}}).
+			Return(plannedReport(), nil)
+
+		services := &handlers.Services{Log: newTestLogger(), UserService: userService}
+
+		b, _ := json.Marshal(map[string]any{
+			"users": []map[string]any{{"user_id": "u1"}},
+			"flag":  false,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/users/deactivate?dry_run=true", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+
+		services.UsersMassDeactivateHandler(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp entity.ReassignmentReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, []string{"pr-1"}, resp.Understaffed)
+		userService.AssertNotCalled(t, "MassDeactivate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassign plans the replacement", func(t *testing.T) {
+		prService := new(MockPRService)
+		report := entity.NewReassignmentReport()
+		report.DryRun = true
+		report.Add(entity.DiffReviewers("pr1", []string{"user2"}, []string{"user3"}))
+		prService.On("PlanReassign", mock.Anything, "pr1", "user2").Return(&entity.PullRequest{
+			PullRequestID:     "pr1",
+			AssignedReviewers: []string{"user3"},
+		}, "user3", report, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), PRService: prService}
+
+		b, _ := json.Marshal(handlers.PRReassignRequest{PullRequestID: "pr1", OldUserID: "user2"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign?dry_run=true", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+
+		services.PRReassignHandler(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp handlers.PRReassignResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "user3", resp.ReplacedBy)
+		require.NotNil(t, resp.Reassignment)
+		assert.Equal(t, []string{"user2"}, resp.Reassignment.PullRequests[0].Removed)
+		prService.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid dry_run returns bad request", func(t *testing.T) {
+		prService := new(MockPRService)
+		services := &handlers.Services{Log: newTestLogger(), PRService: prService}
+
+		b, _ := json.Marshal(handlers.PRReassignRequest{PullRequestID: "pr1", OldUserID: "user2"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign?dry_run=maybe", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+
+		services.PRReassignHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+func TestServices_UsersHandoverHandler(t *testing.T) {
+	tests := []struct {
+		requestBody    interface{}
+		setupMocks     func(*MockUserService)
+		name           string
+		query          string
+		expectedCode   entity.ErrorCode
+		expectedStatus int
+	}{
+		{
+			name:        "single delegate",
+			requestBody: handlers.UserHandoverRequest{FromUserID: "u1", ToUserID: "d1"},
+			setupMocks: func(userService *MockUserService) {
+				report := entity.NewReassignmentReport()
+				report.Add(entity.DiffReviewers("pr-1", []string{"u1"}, []string{"d1"}))
+				userService.On("Handover", mock.Anything, "u1", []string{"d1"}, false).Return(report, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "delegate list as dry run",
+			requestBody: handlers.UserHandoverRequest{FromUserID: "u1", Delegates: []string{"d1", "d2"}},
+			query:       "?dry_run=true",
+			setupMocks: func(userService *MockUserService) {
+				userService.On("Handover", mock.Anything, "u1", []string{"d1", "d2"}, true).
+					Return(entity.NewReassignmentReport(), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "both to_user_id and delegates",
+			requestBody:    handlers.UserHandoverRequest{FromUserID: "u1", ToUserID: "d1", Delegates: []string{"d2"}},
+			setupMocks:     func(*MockUserService) {},
+			expectedCode:   entity.CodeBadRequest,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing delegate",
+			requestBody:    handlers.UserHandoverRequest{FromUserID: "u1"},
+			setupMocks:     func(*MockUserService) {},
+			expectedCode:   entity.CodeBadRequest,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid delegate",
+			requestBody: handlers.UserHandoverRequest{FromUserID: "u1", ToUserID: "d1"},
+			setupMocks: func(userService *MockUserService) {
+				userService.On("Handover", mock.Anything, "u1", []string{"d1"}, false).
+					Return(nil, fmt.Errorf("%w: delegate d1 is not active", entity.ErrInvalidDelegate))
+			},
+			expectedCode:   entity.CodeInvalidDelegate,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "unknown user",
+			requestBody: handlers.UserHandoverRequest{FromUserID: "u1", ToUserID: "d1"},
+			setupMocks: func(userService *MockUserService) {
+				userService.On("Handover", mock.Anything, "u1", []string{"d1"}, false).
+					Return(nil, entity.ErrNotFound)
+			},
+			expectedCode:   entity.CodeNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := new(MockUserService)
+			tt.setupMocks(userService)
+
+			services := &handlers.Services{
+				Log:         newTestLogger(),
+				UserService: userService,
+			}
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/handover"+tt.query, bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			services.UsersHandoverHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedCode != "" {
+				var resp entity.ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+
+			userService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHistoryService) TakeSnapshot(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockHistoryService) GetHistory(
+	ctx context.Context,
+	metric entity.StatsMetric,
+	subject string,
+	from, to time.Time,
+) (*entity.StatsHistory, error) {
+	args := m.Called(ctx, metric, subject, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	history, ok := args.Get(0).(*entity.StatsHistory)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return history, args.Error(1)
+}
+
+func TestServices_StatsHistoryHandler(t *testing.T) {
+	from := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("returns series", func(t *testing.T) {
+		historyService := new(MockHistoryService)
+		historyService.On("GetHistory", mock.Anything, entity.MetricOpenReviewsByUser, "u1", from, to).
+			Return(&entity.StatsHistory{
+				From:   from,
+				To:     to,
+				Metric: entity.MetricOpenReviewsByUser,
+				Series: []*entity.StatsSeries{{
+					Subject: "u1",
+					Points:  []*entity.StatsPoint{{Date: from, Value: 4}},
+				}},
+			}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), HistoryService: historyService}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/stats/history?metric=open_reviews_per_user&subject=u1&from=2025-05-01&to=2025-05-31", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.StatsHistoryHandler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"subject":"u1"`)
+		historyService.AssertExpectations(t)
+	})
+
+	t.Run("unknown metric", func(t *testing.T) {
+		historyService := new(MockHistoryService)
+		services := &handlers.Services{Log: newTestLogger(), HistoryService: historyService}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/history?metric=cpu", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.StatsHistoryHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		historyService.AssertNotCalled(t, "GetHistory",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
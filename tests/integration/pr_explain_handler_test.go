@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+func TestServices_PRExplainHandler(t *testing.T) {
+	tests := []struct {
+		setupMocks     func(*MockPRService)
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{
+			name:  "explains by pull_request_id",
+			query: "pull_request_id=pr-1",
+			setupMocks: func(svc *MockPRService) {
+				svc.On("ExplainAssignment", mock.Anything, "pr-1").
+					Return(&entity.AssignmentExplanation{PullRequestID: "pr-1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "explains by repository and number",
+			query: "repository_name=backend&number=7",
+			setupMocks: func(svc *MockPRService) {
+				svc.On("ExplainAssignment", mock.Anything, entity.PRKey("backend", 7)).
+					Return(&entity.AssignmentExplanation{PullRequestID: entity.PRKey("backend", 7)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing PR",
+			query:          "",
+			setupMocks:     func(_ *MockPRService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "number is not an integer",
+			query:          "repository_name=backend&number=x",
+			setupMocks:     func(_ *MockPRService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "unknown PR",
+			query: "pull_request_id=ghost",
+			setupMocks: func(svc *MockPRService) {
+				svc.On("ExplainAssignment", mock.Anything, "ghost").Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prService := new(MockPRService)
+			tt.setupMocks(prService)
+
+			services := &handlers.Services{Log: newTestLogger(), PRService: prService}
+
+			req := httptest.NewRequest(http.MethodGet, "/pullRequest/explain?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			services.PRExplainHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			prService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("response carries seed and candidates", func(t *testing.T) {
+		prService := new(MockPRService)
+		prService.On("ExplainAssignment", mock.Anything, "pr-1").Return(&entity.AssignmentExplanation{
+			PullRequestID: "pr-1",
+			Decisions: []*entity.AssignmentDecision{
+				{PullRequestID: "pr-1", Seed: 42, Candidates: []string{"u1", "u2"}, Picked: []string{"u2"}},
+			},
+		}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), PRService: prService}
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/explain?pull_request_id=pr-1", nil)
+		w := httptest.NewRecorder()
+
+		services.PRExplainHandler(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp entity.AssignmentExplanation
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Decisions, 1)
+		assert.Equal(t, int64(42), resp.Decisions[0].Seed)
+		assert.Equal(t, []string{"u1", "u2"}, resp.Decisions[0].Candidates)
+	})
+}
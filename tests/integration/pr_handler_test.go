@@ -30,9 +30,9 @@ type MockPRService struct {
 
 func (m *MockPRService) CreatePR(
 	ctx context.Context,
-	prID, prName, authorID string,
+	params entity.PRCreateParams,
 ) (*entity.PullRequest, string, error) {
-	args := m.Called(ctx, prID, prName, authorID)
+	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
 		return nil, args.String(1), args.Error(2)
 	}
@@ -76,6 +76,64 @@ func (m *MockPRService) ReassignReviewer(
 	return pr, args.String(1), args.Error(2)
 }
 
+func (m *MockPRService) AddReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*entity.PullRequest, string, error) {
+	args := m.Called(ctx, prID, userID)
+
+	pr, ok := args.Get(0).(*entity.PullRequest)
+	if !ok {
+		return nil, args.String(1), args.Error(2)
+	}
+
+	return pr, args.String(1), args.Error(2)
+}
+
+func (m *MockPRService) RemoveReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*entity.PullRequest, error) {
+	args := m.Called(ctx, prID, userID)
+
+	pr, ok := args.Get(0).(*entity.PullRequest)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return pr, args.Error(1)
+}
+
+func (m *MockPRService) ExplainAssignment(
+	ctx context.Context,
+	prID string,
+) (*entity.AssignmentExplanation, error) {
+	args := m.Called(ctx, prID)
+
+	explanation, ok := args.Get(0).(*entity.AssignmentExplanation)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return explanation, args.Error(1)
+}
+
+func (m *MockPRService) PlanReassign(
+	ctx context.Context,
+	prID, oldReviewerID string,
+) (*entity.PullRequest, string, *entity.ReassignmentReport, error) {
+	args := m.Called(ctx, prID, oldReviewerID)
+
+	pr, ok := args.Get(0).(*entity.PullRequest)
+	if !ok {
+		return nil, args.String(1), nil, args.Error(3)
+	}
+
+	report, _ := args.Get(2).(*entity.ReassignmentReport)
+
+	return pr, args.String(1), report, args.Error(3)
+}
+
 //nolint:dupl // necessary tests
 func TestServices_PRCreateHandler(t *testing.T) {
 	tests := []struct {
@@ -95,7 +153,11 @@ func TestServices_PRCreateHandler(t *testing.T) {
 			},
 			setupMocks: func(prService *MockPRService) {
 				now := time.Now()
-				prService.On("CreatePR", mock.Anything, "pr1", "Test PR", "user1").Return(&entity.PullRequest{
+				prService.On("CreatePR", mock.Anything, entity.PRCreateParams{
+					PullRequestID:   "pr1",
+					PullRequestName: "Test PR",
+					AuthorID:        "user1",
+				}).Return(&entity.PullRequest{
 					PullRequestID:     "pr1",
 					PullRequestName:   "Test PR",
 					AuthorID:          "user1",
@@ -113,6 +175,110 @@ func TestServices_PRCreateHandler(t *testing.T) {
 				assert.Equal(t, "pr1", resp.PR.PullRequestID)
 			},
 		},
+		{
+			name: "pinned and excluded reviewers are passed through",
+			requestBody: handlers.PRCreateRequest{
+				PullRequestID:     "pr1",
+				PullRequestName:   "Test PR",
+				AuthorID:          "user1",
+				RequiredReviewers: []string{"user2"},
+				ExcludedReviewers: []string{"user3"},
+			},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("CreatePR", mock.Anything, entity.PRCreateParams{
+					PullRequestID:     "pr1",
+					PullRequestName:   "Test PR",
+					AuthorID:          "user1",
+					RequiredReviewers: []string{"user2"},
+					ExcludedReviewers: []string{"user3"},
+				}).Return(&entity.PullRequest{
+					PullRequestID:     "pr1",
+					AssignedReviewers: []string{"user2", "user4"},
+				}, "", nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp handlers.PRCreateResponse
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"user2", "user4"}, resp.PR.AssignedReviewers)
+			},
+		},
+		{
+			name: "reviewer both required and excluded",
+			requestBody: handlers.PRCreateRequest{
+				PullRequestID:     "pr1",
+				PullRequestName:   "Test PR",
+				AuthorID:          "user1",
+				RequiredReviewers: []string{"user2"},
+				ExcludedReviewers: []string{"user2"},
+			},
+			setupMocks:     func(prService *MockPRService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp entity.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, entity.CodeBadRequest, resp.Error.Code)
+			},
+		},
+		{
+			name: "legacy pull_request_id with '#'",
+			requestBody: handlers.PRCreateRequest{
+				PullRequestID:   "backend#7",
+				PullRequestName: "Test PR",
+				AuthorID:        "user1",
+			},
+			setupMocks:     func(prService *MockPRService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp entity.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, entity.CodeBadRequest, resp.Error.Code)
+			},
+		},
+		{
+			name: "repository_name with '#'",
+			requestBody: handlers.PRCreateRequest{
+				RepositoryName:  "back#end",
+				Number:          7,
+				PullRequestName: "Test PR",
+				AuthorID:        "user1",
+			},
+			setupMocks:     func(prService *MockPRService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp entity.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, entity.CodeBadRequest, resp.Error.Code)
+			},
+		},
+		{
+			name: "INVALID_REVIEWER error",
+			requestBody: handlers.PRCreateRequest{
+				PullRequestID:     "pr1",
+				PullRequestName:   "Test PR",
+				AuthorID:          "user1",
+				RequiredReviewers: []string{"stranger"},
+			},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("CreatePR", mock.Anything, mock.Anything).
+					Return(nil, "", entity.ErrInvalidReviewer)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp entity.ErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, entity.CodeInvalidReviewer, resp.Error.Code)
+			},
+		},
 		{
 			name:           "invalid JSON",
 			requestBody:    "invalid json",
@@ -134,7 +300,11 @@ func TestServices_PRCreateHandler(t *testing.T) {
 				AuthorID:        "user1",
 			},
 			setupMocks: func(prService *MockPRService) {
-				prService.On("CreatePR", mock.Anything, "pr1", "Test PR", "user1").Return(nil, "", entity.ErrPRExists)
+				prService.On("CreatePR", mock.Anything, entity.PRCreateParams{
+					PullRequestID:   "pr1",
+					PullRequestName: "Test PR",
+					AuthorID:        "user1",
+				}).Return(nil, "", entity.ErrPRExists)
 			},
 			expectedStatus: http.StatusConflict,
 			expectedError:  true,
@@ -153,7 +323,11 @@ func TestServices_PRCreateHandler(t *testing.T) {
 				AuthorID:        "user1",
 			},
 			setupMocks: func(prService *MockPRService) {
-				prService.On("CreatePR", mock.Anything, "pr1", "Test PR", "user1").Return(nil, "", entity.ErrNotFound)
+				prService.On("CreatePR", mock.Anything, entity.PRCreateParams{
+					PullRequestID:   "pr1",
+					PullRequestName: "Test PR",
+					AuthorID:        "user1",
+				}).Return(nil, "", entity.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  true,
@@ -172,7 +346,11 @@ func TestServices_PRCreateHandler(t *testing.T) {
 				AuthorID:        "user1",
 			},
 			setupMocks: func(prService *MockPRService) {
-				prService.On("CreatePR", mock.Anything, "pr1", "Test PR", "user1").Return(nil, "", errors.New("db error"))
+				prService.On("CreatePR", mock.Anything, entity.PRCreateParams{
+					PullRequestID:   "pr1",
+					PullRequestName: "Test PR",
+					AuthorID:        "user1",
+				}).Return(nil, "", errors.New("db error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  true,
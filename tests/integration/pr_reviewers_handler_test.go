@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+func TestServices_PRReviewersHandlers(t *testing.T) {
+	tests := []struct {
+		requestBody    handlers.PRReviewerRequest
+		setupMocks     func(*MockPRService)
+		name           string
+		remove         bool
+		expectedCode   entity.ErrorCode
+		expectedStatus int
+	}{
+		{
+			name:        "add picks a reviewer automatically",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1"},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("AddReviewer", mock.Anything, "pr1", "").Return(&entity.PullRequest{
+					PullRequestID:     "pr1",
+					AssignedReviewers: []string{"user2"},
+				}, "user2", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "add by repository and number",
+			requestBody: handlers.PRReviewerRequest{RepositoryName: "backend", Number: 7, UserID: "user2"},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("AddReviewer", mock.Anything, "backend#7", "user2").
+					Return(&entity.PullRequest{PullRequestID: "backend#7"}, "user2", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "add to a full PR",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1"},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("AddReviewer", mock.Anything, "pr1", "").Return(nil, "", entity.ErrReviewerLimit)
+			},
+			expectedCode:   entity.CodeReviewerLimit,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "add a member of another team",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1", UserID: "user9"},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("AddReviewer", mock.Anything, "pr1", "user9").Return(nil, "", entity.ErrInvalidReviewer)
+			},
+			expectedCode:   entity.CodeInvalidReviewer,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "add a member at their review limit",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1", UserID: "user3"},
+			setupMocks: func(prService *MockPRService) {
+				prService.On("AddReviewer", mock.Anything, "pr1", "user3").
+					Return(nil, "", entity.ErrReviewerUnavailable)
+			},
+			expectedCode:   entity.CodeReviewerUnavailable,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "remove a reviewer",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1", UserID: "user2"},
+			remove:      true,
+			setupMocks: func(prService *MockPRService) {
+				prService.On("RemoveReviewer", mock.Anything, "pr1", "user2").
+					Return(&entity.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "remove without user_id",
+			requestBody:    handlers.PRReviewerRequest{PullRequestID: "pr1"},
+			remove:         true,
+			setupMocks:     func(*MockPRService) {},
+			expectedCode:   entity.CodeBadRequest,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "remove a reviewer not on the PR",
+			requestBody: handlers.PRReviewerRequest{PullRequestID: "pr1", UserID: "user3"},
+			remove:      true,
+			setupMocks: func(prService *MockPRService) {
+				prService.On("RemoveReviewer", mock.Anything, "pr1", "user3").Return(nil, entity.ErrNotAssigned)
+			},
+			expectedCode:   entity.CodeNotAssigned,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prService := new(MockPRService)
+			tt.setupMocks(prService)
+
+			services := &handlers.Services{
+				Log:       newTestLogger(),
+				PRService: prService,
+			}
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			if tt.remove {
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/reviewers/remove", bytes.NewBuffer(body))
+				services.PRRemoveReviewerHandler(w, req)
+			} else {
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/reviewers/add", bytes.NewBuffer(body))
+				services.PRAddReviewerHandler(w, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedCode != "" {
+				var resp entity.ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+
+			prService.AssertExpectations(t)
+		})
+	}
+}
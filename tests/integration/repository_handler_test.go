@@ -0,0 +1,209 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockRepositoryService struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryService) AddRepository(
+	ctx context.Context,
+	repository *entity.Repository,
+) (*entity.Repository, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).(*entity.Repository)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockRepositoryService) GetRepository(ctx context.Context, name string) (*entity.Repository, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	res, ok := args.Get(0).(*entity.Repository)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockRepositoryService) ListPullRequests(
+	ctx context.Context,
+	name string,
+	status entity.PRStatus,
+) ([]*entity.PullRequestShort, error) {
+	args := m.Called(ctx, name, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	prs, ok := args.Get(0).([]*entity.PullRequestShort)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return prs, args.Error(1)
+}
+
+func TestServices_RepositoryAddHandler(t *testing.T) {
+	tests := []struct {
+		requestBody    interface{}
+		setupMocks     func(*MockRepositoryService)
+		name           string
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: entity.Repository{RepositoryName: "backend", DefaultTeam: "team1"},
+			setupMocks: func(svc *MockRepositoryService) {
+				svc.On("AddRepository", mock.Anything, mock.AnythingOfType("*entity.Repository")).
+					Return(&entity.Repository{RepositoryName: "backend", VCS: "git", DefaultTeam: "team1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "name with separator is rejected",
+			requestBody:    entity.Repository{RepositoryName: "back#end"},
+			setupMocks:     func(_ *MockRepositoryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "repository exists",
+			requestBody: entity.Repository{RepositoryName: "backend"},
+			setupMocks: func(svc *MockRepositoryService) {
+				svc.On("AddRepository", mock.Anything, mock.Anything).Return(nil, entity.ErrRepositoryExists)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "default team not found",
+			requestBody: entity.Repository{RepositoryName: "backend", DefaultTeam: "ghost"},
+			setupMocks: func(svc *MockRepositoryService) {
+				svc.On("AddRepository", mock.Anything, mock.Anything).Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockRepositoryService)
+			tt.setupMocks(svc)
+
+			services := &handlers.Services{
+				Log:               newTestLogger(),
+				RepositoryService: svc,
+			}
+
+			b, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/repository/add", bytes.NewBuffer(b))
+			w := httptest.NewRecorder()
+
+			services.RepositoryAddHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServices_RepositoryPRListHandler(t *testing.T) {
+	t.Run("lists repository PRs", func(t *testing.T) {
+		svc := new(MockRepositoryService)
+		svc.On("ListPullRequests", mock.Anything, "backend", entity.OPEN).
+			Return([]*entity.PullRequestShort{
+				{PullRequestID: "backend#1", RepositoryName: "backend", Number: 1, Status: entity.OPEN},
+			}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), RepositoryService: svc}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/repository/pullRequests?repository_name=backend&status=OPEN", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.RepositoryPRListHandler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp handlers.RepositoryPRListResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.PullRequests, 1)
+		assert.Equal(t, "backend#1", resp.PullRequests[0].PullRequestID)
+
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		services := &handlers.Services{Log: newTestLogger(), RepositoryService: new(MockRepositoryService)}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/repository/pullRequests?repository_name=backend&status=CLOSED", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.RepositoryPRListHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		svc := new(MockRepositoryService)
+		svc.On("ListPullRequests", mock.Anything, "ghost", entity.PRStatus("")).Return(nil, entity.ErrNotFound)
+
+		services := &handlers.Services{Log: newTestLogger(), RepositoryService: svc}
+
+		req := httptest.NewRequest(http.MethodGet, "/repository/pullRequests?repository_name=ghost", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.RepositoryPRListHandler(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestServices_PRMergeHandler_RepositoryNumber(t *testing.T) {
+	prService := new(MockPRService)
+	prService.On("MergePR", mock.Anything, "backend#3").Return(&entity.PullRequest{
+		PullRequestID:  "backend#3",
+		RepositoryName: "backend",
+		Number:         3,
+		Status:         entity.MERGED,
+	}, nil)
+
+	services := &handlers.Services{Log: newTestLogger(), PRService: prService}
+
+	b, err := json.Marshal(handlers.PRMergeRequest{RepositoryName: "backend", Number: 3})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(b))
+	w := httptest.NewRecorder()
+
+	services.PRMergeHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	prService.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/server"
+	"Service-for-assigning-reviewers-for-Pull-Requests/pkg/tracing"
+)
+
+func TestServices_HandlerLogsCarryTraceID(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	var buf bytes.Buffer
+	services := &handlers.Services{
+		Log:             slog.New(tracing.NewLogHandler(slog.NewJSONHandler(&buf, nil))),
+		ConflictService: new(MockConflictService),
+	}
+
+	r := chi.NewRouter()
+	server.RegisterRoutes(services, r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/conflicts/add", strings.NewReader("{")))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2, "the handler warning and the request line")
+
+	var warning, completed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &warning))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &completed))
+
+	assert.Equal(t, "failed to decode conflict request", warning["msg"])
+	assert.NotEmpty(t, warning["trace_id"])
+	assert.NotEmpty(t, warning["span_id"])
+	assert.Equal(t, completed["trace_id"], warning["trace_id"])
+	assert.Equal(t, 1, strings.Count(lines[1], `"trace_id"`), "the trace is not added twice")
+}
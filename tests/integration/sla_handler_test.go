@@ -0,0 +1,158 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
+)
+
+type MockSLAService struct {
+	mock.Mock
+}
+
+func (m *MockSLAService) GetOverdue(ctx context.Context) (*entity.OverdueReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	report, ok := args.Get(0).(*entity.OverdueReport)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return report, args.Error(1)
+}
+
+func (m *MockSLAService) CheckOverdue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestServices_OverdueReviewsHandler(t *testing.T) {
+	t.Run("returns overdue reviews", func(t *testing.T) {
+		slaService := new(MockSLAService)
+		slaService.On("GetOverdue", mock.Anything).Return(&entity.OverdueReport{
+			PerTeam: map[string]int{"team1": 1},
+			Reviews: []*entity.OverdueReview{
+				{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "team1", SLAMinutes: 60},
+			},
+		}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), SLAService: slaService}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/overdue", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.OverdueReviewsHandler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp entity.OverdueReport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.PerTeam["team1"])
+		assert.Equal(t, "pr1", resp.Reviews[0].PullRequestID)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		slaService := new(MockSLAService)
+		slaService.On("GetOverdue", mock.Anything).Return(nil, errors.New("db error"))
+
+		services := &handlers.Services{Log: newTestLogger(), SLAService: slaService}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/overdue", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.OverdueReviewsHandler(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestServices_TeamSetReviewSLAHandler(t *testing.T) {
+	sla := 90
+
+	tests := []struct {
+		requestBody    handlers.TeamSetReviewSLARequest
+		setupMocks     func(*MockTeamService)
+		name           string
+		expectedCode   entity.ErrorCode
+		expectedStatus int
+	}{
+		{
+			name: "successful update",
+			requestBody: handlers.TeamSetReviewSLARequest{
+				TeamName: "team1", ReviewSLAMinutes: &sla, LeadUserID: "u1",
+			},
+			setupMocks: func(teamService *MockTeamService) {
+				teamService.On("SetReviewSLA", mock.Anything, "team1", &sla, "u1").
+					Return(&entity.Team{TeamName: "team1", ReviewSLAMinutes: &sla, LeadUserID: "u1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing team name",
+			requestBody:    handlers.TeamSetReviewSLARequest{ReviewSLAMinutes: &sla},
+			setupMocks:     func(teamService *MockTeamService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   entity.CodeBadRequest,
+		},
+		{
+			name: "lead outside the team",
+			requestBody: handlers.TeamSetReviewSLARequest{
+				TeamName: "team1", ReviewSLAMinutes: &sla, LeadUserID: "x",
+			},
+			setupMocks: func(teamService *MockTeamService) {
+				teamService.On("SetReviewSLA", mock.Anything, "team1", &sla, "x").
+					Return(nil, entity.ErrLeadNotMember)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   entity.CodeLeadNotMember,
+		},
+		{
+			name:        "team not found",
+			requestBody: handlers.TeamSetReviewSLARequest{TeamName: "ghost"},
+			setupMocks: func(teamService *MockTeamService) {
+				teamService.On("SetReviewSLA", mock.Anything, "ghost", (*int)(nil), "").
+					Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   entity.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamService := new(MockTeamService)
+			tt.setupMocks(teamService)
+
+			services := &handlers.Services{Log: newTestLogger(), TeamService: teamService}
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/team/setReviewSLA", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			services.TeamSetReviewSLAHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp entity.ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			teamService.AssertExpectations(t)
+		})
+	}
+}
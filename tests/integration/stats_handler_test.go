@@ -7,10 +7,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/service"
 )
@@ -27,6 +29,14 @@ func (m *MockStatsRepo) GetAssignedReviewersCountPerPR(ctx context.Context) (map
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *MockStatsRepo) GetOpenPRCountByReviewerCount(ctx context.Context) (map[int]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]int), args.Error(1)
+}
+
 func (m *MockStatsRepo) GetOpenPRCountPerUser(ctx context.Context) (map[string]int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -35,20 +45,85 @@ func (m *MockStatsRepo) GetOpenPRCountPerUser(ctx context.Context) (map[string]i
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *MockStatsRepo) GetOpenPRCountPerRepository(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockStatsRepo) GetTurnaround(
+	ctx context.Context,
+	from, to time.Time,
+	groupBy entity.TurnaroundGroupBy,
+) ([]*entity.TurnaroundGroup, error) {
+	args := m.Called(ctx, from, to, groupBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.TurnaroundGroup)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetFairnessData(
+	ctx context.Context,
+	teamName string,
+	from, to time.Time,
+) ([]*entity.FairnessMember, error) {
+	args := m.Called(ctx, teamName, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.FairnessMember)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetTeamLoad(ctx context.Context) ([]*entity.TeamLoad, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	v, ok := args.Get(0).([]*entity.TeamLoad)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return v, args.Error(1)
+}
+
+func (m *MockStatsRepo) GetOverdueCountPerTeam(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
 func TestMetricsHandler_ServesMetrics(t *testing.T) {
 	mockRepo := new(MockStatsRepo)
 	svc := service.NewStatsService(mockRepo)
 
-	prCounts := map[string]int{"pr-1": 1, "pr-2": 2}
-	userCounts := map[string]int{"u1": 1}
-
-	mockRepo.On("GetAssignedReviewersCountPerPR", mock.Anything).Return(prCounts, nil)
-	mockRepo.On("GetOpenPRCountPerUser", mock.Anything).Return(userCounts, nil)
+	// counts above the last label are summed into it
+	mockRepo.On("GetOpenPRCountByReviewerCount", mock.Anything).Return(map[int]int{1: 1, 2: 2, 3: 1, 4: 1}, nil)
+	mockRepo.On("GetOpenPRCountPerRepository", mock.Anything).Return(map[string]int{"backend": 3}, nil)
+	mockRepo.On("GetTeamLoad", mock.Anything).Return([]*entity.TeamLoad{
+		{TeamName: "payments", OpenPRs: 3, OpenReviews: 5, MaxMemberReviews: 3, ActiveMembers: 4},
+	}, nil)
+	mockRepo.On("GetOverdueCountPerTeam", mock.Anything).Return(map[string]int{"payments": 2}, nil)
 
 	services := &handlers.Services{
 		Log:          newTestLogger(),
 		StatsService: svc}
 
+	assert.NoError(t, services.UpdateMetrics(context.Background()))
+	mockRepo.AssertExpectations(t)
+
 	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
 	w := httptest.NewRecorder()
 
@@ -58,76 +133,164 @@ func TestMetricsHandler_ServesMetrics(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	body := w.Body.String()
 
-	assert.True(t, strings.Contains(body, "assigned_reviewers_per_pr"))
-	assert.True(t, strings.Contains(body, "open_prs_per_user"))
-	assert.True(t, strings.Contains(body, "pr-1"))
-	assert.True(t, strings.Contains(body, "pr-2"))
-	assert.True(t, strings.Contains(body, "u1"))
+	assert.Contains(t, body, "open_pull_requests 5")
+	assert.Contains(t, body, `open_prs_by_reviewer_count{reviewers="0"} 0`)
+	assert.Contains(t, body, `open_prs_by_reviewer_count{reviewers="2"} 2`)
+	assert.Contains(t, body, `open_prs_by_reviewer_count{reviewers="3+"} 2`)
+	assert.Contains(t, body, `open_prs_per_repository{repository_name="backend"} 3`)
+	assert.Contains(t, body, `open_reviews_per_team{team_name="payments"} 5`)
+	assert.Contains(t, body, `max_open_reviews_per_member{team_name="payments"} 3`)
+	assert.Contains(t, body, `overdue_reviews_per_team{team_name="payments"} 2`)
+
+	// per-PR and per-user series are gone
+	assert.False(t, strings.Contains(body, "pr-1"))
+	assert.False(t, strings.Contains(body, "assigned_reviewers_per_pr"))
+	assert.False(t, strings.Contains(body, "open_prs_per_user"))
+}
 
-	mockRepo.AssertExpectations(t)
+func TestMetricsHandler_DoesNotQueryStats(t *testing.T) {
+	mockRepo := new(MockStatsRepo)
+
+	services := &handlers.Services{
+		Log:          newTestLogger(),
+		StatsService: service.NewStatsService(mockRepo)}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	w := httptest.NewRecorder()
+
+	services.MetricsHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	mockRepo.AssertNotCalled(t, "GetOpenPRCountByReviewerCount", mock.Anything)
 }
 
-func TestMetricsHandler_ErrorPaths(t *testing.T) {
+func TestUpdateMetrics_ErrorPaths(t *testing.T) {
 	t.Run("pr counts error", func(t *testing.T) {
 		mockRepo := new(MockStatsRepo)
 		svc := service.NewStatsService(mockRepo)
 
-		mockRepo.On("GetAssignedReviewersCountPerPR", mock.Anything).Return(nil, errors.New("db error"))
+		mockRepo.On("GetOpenPRCountByReviewerCount", mock.Anything).Return(nil, errors.New("db error"))
 
 		services := &handlers.Services{
 			Log:          newTestLogger(),
 			StatsService: svc}
 
-		req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
-		w := httptest.NewRecorder()
-
-		services.MetricsHandler(w, req)
-
-		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
-		assert.Contains(t, w.Body.String(), "failed to get PR counts")
-
+		assert.Error(t, services.UpdateMetrics(context.Background()))
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("user counts error", func(t *testing.T) {
+	t.Run("team load error", func(t *testing.T) {
 		mockRepo := new(MockStatsRepo)
 		svc := service.NewStatsService(mockRepo)
 
-		mockRepo.On("GetAssignedReviewersCountPerPR", mock.Anything).Return(map[string]int{}, nil)
-		mockRepo.On("GetOpenPRCountPerUser", mock.Anything).Return(nil, errors.New("db error"))
+		mockRepo.On("GetOpenPRCountByReviewerCount", mock.Anything).Return(map[int]int{}, nil)
+		mockRepo.On("GetOpenPRCountPerRepository", mock.Anything).Return(map[string]int{}, nil)
+		mockRepo.On("GetTeamLoad", mock.Anything).Return(nil, errors.New("db error"))
 
 		services := &handlers.Services{
 			Log:          newTestLogger(),
 			StatsService: svc}
 
-		req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
-		w := httptest.NewRecorder()
+		assert.Error(t, services.UpdateMetrics(context.Background()))
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetOverdueCountPerTeam", mock.Anything)
+	})
+}
 
-		services.MetricsHandler(w, req)
+func TestTurnaroundHandler(t *testing.T) {
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
-		assert.Contains(t, w.Body.String(), "failed to get user counts")
+	t.Run("groups by user over the given period", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		svc := service.NewStatsService(mockRepo)
+
+		mockRepo.On("GetTurnaround", mock.Anything, from, to, entity.GroupByUser).
+			Return([]*entity.TurnaroundGroup{{Key: "u1"}}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), StatsService: svc}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/stats/turnaround?from=2025-01-01&to=2025-02-01T00:00:00Z&group_by=user", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.TurnaroundHandler(w, req)
 
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"key":"u1"`)
 		mockRepo.AssertExpectations(t)
 	})
+
+	for name, query := range map[string]string{
+		"bad group":      "?group_by=repository",
+		"bad date":       "?from=yesterday",
+		"reversed range": "?from=2025-02-01&to=2025-01-01",
+	} {
+		t.Run(name, func(t *testing.T) {
+			services := &handlers.Services{
+				Log:          newTestLogger(),
+				StatsService: service.NewStatsService(new(MockStatsRepo)),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/stats/turnaround"+query, http.NoBody)
+			w := httptest.NewRecorder()
+
+			services.TurnaroundHandler(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
 }
 
-func TestUpdateMetrics_Method(t *testing.T) {
-	mockRepo := new(MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+func TestFairnessHandler(t *testing.T) {
+	t.Run("reports team members", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		svc := service.NewStatsService(mockRepo)
 
-	prCounts := map[string]int{"pr-1": 1}
-	userCounts := map[string]int{"u1": 1}
+		mockRepo.On("GetFairnessData", mock.Anything, "team1", mock.Anything, mock.Anything).
+			Return([]*entity.FairnessMember{
+				{UserID: "u1", IsActive: true, Assignments: 4, ActiveDays: 30},
+				{UserID: "u2", IsActive: true, Assignments: 0, ActiveDays: 30},
+			}, nil)
 
-	mockRepo.On("GetAssignedReviewersCountPerPR", mock.Anything).Return(prCounts, nil)
-	mockRepo.On("GetOpenPRCountPerUser", mock.Anything).Return(userCounts, nil)
+		services := &handlers.Services{Log: newTestLogger(), StatsService: svc}
 
-	services := &handlers.Services{
-		Log:          newTestLogger(),
-		StatsService: svc}
+		req := httptest.NewRequest(http.MethodGet, "/stats/fairness?team=team1&threshold=0.3", http.NoBody)
+		w := httptest.NewRecorder()
 
-	err := services.UpdateMetrics(context.Background())
-	assert.NoError(t, err)
+		services.FairnessHandler(w, req)
 
-	mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"overloaded":["u1"]`)
+		assert.Contains(t, w.Body.String(), `"underloaded":["u2"]`)
+	})
+
+	t.Run("team is required", func(t *testing.T) {
+		services := &handlers.Services{
+			Log:          newTestLogger(),
+			StatsService: service.NewStatsService(new(MockStatsRepo)),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/fairness", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.FairnessHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		mockRepo := new(MockStatsRepo)
+		mockRepo.On("GetFairnessData", mock.Anything, "ghost", mock.Anything, mock.Anything).
+			Return([]*entity.FairnessMember{}, nil)
+
+		services := &handlers.Services{Log: newTestLogger(), StatsService: service.NewStatsService(mockRepo)}
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/fairness?team=ghost", http.NoBody)
+		w := httptest.NewRecorder()
+
+		services.FairnessHandler(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
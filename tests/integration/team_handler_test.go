@@ -48,6 +48,25 @@ func (m *MockTeamService) GetTeam(ctx context.Context, teamName string) (*entity
 	return team, args.Error(1)
 }
 
+func (m *MockTeamService) SetReviewSLA(
+	ctx context.Context,
+	teamName string,
+	slaMinutes *int,
+	leadUserID string,
+) (*entity.Team, error) {
+	args := m.Called(ctx, teamName, slaMinutes, leadUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	team, ok := args.Get(0).(*entity.Team)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return team, args.Error(1)
+}
+
 func TestServices_TeamAddHandler(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -24,18 +24,64 @@ func (m *MockUserService) ChangeStatus(
 	ctx context.Context,
 	userID string,
 	isActive bool,
-) (*entity.User, error) {
+) (*entity.User, *entity.ReassignmentReport, error) {
 	args := m.Called(ctx, userID, isActive)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+
+	user, ok := args.Get(0).(*entity.User)
+	if !ok {
+		return nil, nil, args.Error(2)
 	}
 
+	report, _ := args.Get(1).(*entity.ReassignmentReport)
+
+	return user, report, args.Error(2)
+}
+
+func (m *MockUserService) PlanStatusChange(
+	ctx context.Context,
+	userID string,
+	isActive bool,
+) (*entity.User, *entity.ReassignmentReport, error) {
+	args := m.Called(ctx, userID, isActive)
+
 	user, ok := args.Get(0).(*entity.User)
+	if !ok {
+		return nil, nil, args.Error(2)
+	}
+
+	report, _ := args.Get(1).(*entity.ReassignmentReport)
+
+	return user, report, args.Error(2)
+}
+
+func (m *MockUserService) PlanMassDeactivate(
+	ctx context.Context,
+	users []entity.User,
+) (*entity.ReassignmentReport, error) {
+	args := m.Called(ctx, users)
+
+	report, ok := args.Get(0).(*entity.ReassignmentReport)
 	if !ok {
 		return nil, args.Error(1)
 	}
 
-	return user, args.Error(1)
+	return report, args.Error(1)
+}
+
+func (m *MockUserService) Handover(
+	ctx context.Context,
+	fromUserID string,
+	delegateIDs []string,
+	dryRun bool,
+) (*entity.ReassignmentReport, error) {
+	args := m.Called(ctx, fromUserID, delegateIDs, dryRun)
+
+	report, ok := args.Get(0).(*entity.ReassignmentReport)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return report, args.Error(1)
 }
 
 func (m *MockUserService) GetPRsAssignedTo(
@@ -55,9 +101,85 @@ func (m *MockUserService) GetPRsAssignedTo(
 	return args.String(0), prs, args.Error(2)
 }
 
-func (m *MockUserService) MassDeactivate(ctx context.Context, users []entity.User, flag bool) error {
+func (m *MockUserService) MassDeactivate(
+	ctx context.Context,
+	users []entity.User,
+	flag bool,
+) (*entity.ReassignmentReport, error) {
 	args := m.Called(ctx, users, flag)
-	return args.Error(0)
+
+	report, ok := args.Get(0).(*entity.ReassignmentReport)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return report, args.Error(1)
+}
+
+func (m *MockUserService) BulkSetIsActive(
+	ctx context.Context,
+	changes []entity.StatusChange,
+) *entity.BulkStatusReport {
+	args := m.Called(ctx, changes)
+
+	report, ok := args.Get(0).(*entity.BulkStatusReport)
+	if !ok {
+		return nil
+	}
+
+	return report
+}
+
+func (m *MockUserService) GetReviewCapacity(
+	ctx context.Context,
+	userID string,
+) (*entity.ReviewCapacity, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	capacity, ok := args.Get(0).(*entity.ReviewCapacity)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return capacity, args.Error(1)
+}
+
+func (m *MockUserService) SetMaxOpenReviews(
+	ctx context.Context,
+	userID string,
+	maxOpenReviews *int,
+) (*entity.User, error) {
+	args := m.Called(ctx, userID, maxOpenReviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	user, ok := args.Get(0).(*entity.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return user, args.Error(1)
+}
+
+func (m *MockUserService) SetWorkingHours(
+	ctx context.Context,
+	userID, timeZone, workStart, workEnd string,
+) (*entity.User, error) {
+	args := m.Called(ctx, userID, timeZone, workStart, workEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	user, ok := args.Get(0).(*entity.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return user, args.Error(1)
 }
 
 func TestServices_UserSetIsActiveHandler(t *testing.T) {
@@ -82,7 +204,7 @@ func TestServices_UserSetIsActiveHandler(t *testing.T) {
 					Username: "testuser",
 					TeamName: "team1",
 					IsActive: true,
-				}, nil)
+				}, entity.NewReassignmentReport(), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedError:  false,
@@ -114,7 +236,7 @@ func TestServices_UserSetIsActiveHandler(t *testing.T) {
 				IsActive: true,
 			},
 			setupMocks: func(userService *MockUserService) {
-				userService.On("ChangeStatus", mock.Anything, "user1", true).Return(nil, entity.ErrNotFound)
+				userService.On("ChangeStatus", mock.Anything, "user1", true).Return(nil, nil, entity.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  true,
@@ -132,7 +254,7 @@ func TestServices_UserSetIsActiveHandler(t *testing.T) {
 				IsActive: true,
 			},
 			setupMocks: func(userService *MockUserService) {
-				userService.On("ChangeStatus", mock.Anything, "user1", true).Return(nil, errors.New("db error"))
+				userService.On("ChangeStatus", mock.Anything, "user1", true).Return(nil, nil, errors.New("db error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  true,
@@ -155,6 +277,16 @@ func TestServices_UserSetIsActiveHandler(t *testing.T) {
 					Username: "testuser",
 					TeamName: "team1",
 					IsActive: false,
+				}, &entity.ReassignmentReport{
+					DeactivatedUserIDs: []string{"user1"},
+					PullRequests: []*entity.PRReassignment{{
+						PullRequestID: "pr1",
+						Removed:       []string{"user1"},
+						Added:         []string{"user3"},
+						Reviewers:     []string{"user3"},
+					}},
+					Understaffed:     []string{},
+					WithoutReviewers: []string{},
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -165,6 +297,10 @@ func TestServices_UserSetIsActiveHandler(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, "user1", resp.User.UserID)
 				assert.False(t, resp.User.IsActive)
+				if assert.NotNil(t, resp.Reassignment) {
+					assert.False(t, resp.Reassignment.DryRun)
+					assert.Equal(t, []string{"user3"}, resp.Reassignment.PullRequests[0].Added)
+				}
 			},
 		},
 	}
@@ -229,6 +365,9 @@ func TestServices_UserGetReviewHandler(t *testing.T) {
 						Status:          entity.MERGED,
 					},
 				}, nil)
+				limit := 3
+				userService.On("GetReviewCapacity", mock.Anything, "user1").
+					Return(entity.NewReviewCapacity(&limit, 1), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedError:  false,
@@ -238,6 +377,8 @@ func TestServices_UserGetReviewHandler(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, "user1", resp.UserID)
 				assert.Len(t, resp.PullRequests, 2)
+				assert.NotNil(t, resp.Capacity)
+				assert.Equal(t, 2, *resp.Capacity.Remaining)
 			},
 		},
 		{
@@ -287,6 +428,8 @@ func TestServices_UserGetReviewHandler(t *testing.T) {
 			userID: "user1",
 			setupMocks: func(userService *MockUserService) {
 				userService.On("GetPRsAssignedTo", mock.Anything, "user1").Return("user1", []*entity.PullRequestShort{}, nil)
+				userService.On("GetReviewCapacity", mock.Anything, "user1").
+					Return(entity.NewReviewCapacity(nil, 0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedError:  false,
@@ -321,3 +464,169 @@ func TestServices_UserGetReviewHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestServices_UserSetWorkingHoursHandler(t *testing.T) {
+	tests := []struct {
+		requestBody    interface{}
+		setupMocks     func(*MockUserService)
+		name           string
+		expectedStatus int
+	}{
+		{
+			name: "successful update",
+			requestBody: handlers.UserSetWorkingHoursRequest{
+				UserID:    "user1",
+				TimeZone:  "Europe/Moscow",
+				WorkStart: "10:00",
+				WorkEnd:   "19:00",
+			},
+			setupMocks: func(userService *MockUserService) {
+				userService.On("SetWorkingHours", mock.Anything,
+					"user1", "Europe/Moscow", "10:00", "19:00").
+					Return(&entity.User{
+						UserID:    "user1",
+						TimeZone:  "Europe/Moscow",
+						WorkStart: "10:00",
+						WorkEnd:   "19:00",
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown time zone",
+			requestBody: handlers.UserSetWorkingHoursRequest{
+				UserID:    "user1",
+				TimeZone:  "Mars/Olympus",
+				WorkStart: "10:00",
+				WorkEnd:   "19:00",
+			},
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "malformed hours",
+			requestBody: handlers.UserSetWorkingHoursRequest{
+				UserID:    "user1",
+				WorkStart: "9am",
+				WorkEnd:   "18:00",
+			},
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "only one bound",
+			requestBody: handlers.UserSetWorkingHoursRequest{
+				UserID:    "user1",
+				WorkStart: "09:00",
+			},
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "user not found",
+			requestBody: handlers.UserSetWorkingHoursRequest{
+				UserID: "ghost",
+			},
+			setupMocks: func(userService *MockUserService) {
+				userService.On("SetWorkingHours", mock.Anything, "ghost", "UTC", "", "").
+					Return(nil, entity.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := new(MockUserService)
+			tt.setupMocks(userService)
+
+			services := &handlers.Services{
+				Log:         newTestLogger(),
+				UserService: userService,
+			}
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			services.UserSetWorkingHoursHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServices_UsersBulkSetIsActiveHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMocks     func(userService *MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "mixed batch reports per user",
+			requestBody: `{"users": [{"user_id": "u1", "is_active": true}, {"user_id": "u2", "is_active": false}]}`,
+			setupMocks: func(userService *MockUserService) {
+				userService.On("BulkSetIsActive", mock.Anything, []entity.StatusChange{
+					{UserID: "u1", IsActive: true},
+					{UserID: "u2", IsActive: false},
+				}).Return(&entity.BulkStatusReport{
+					Results: []entity.BulkStatusResult{
+						{UserID: "u1", Status: entity.BulkStatusUpdated, IsActive: true},
+						{UserID: "u2", Status: entity.BulkStatusNotFound, Error: "NOT_FOUND"},
+					},
+					Updated: 1,
+					Failed:  1,
+				})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"not_found"`,
+		},
+		{
+			name:           "empty list",
+			requestBody:    `{"users": []}`,
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `EMPTY_REQUEST`,
+		},
+		{
+			name:           "duplicate user",
+			requestBody:    `{"users": [{"user_id": "u1"}, {"user_id": "u1", "is_active": true}]}`,
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `duplicate user_id u1`,
+		},
+		{
+			name:           "missing user id",
+			requestBody:    `{"users": [{"is_active": true}]}`,
+			setupMocks:     func(userService *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := new(MockUserService)
+			tt.setupMocks(userService)
+
+			services := &handlers.Services{
+				Log:         newTestLogger(),
+				UserService: userService,
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/bulkSetIsActive",
+				bytes.NewBufferString(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			services.UsersBulkSetIsActiveHandler(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			userService.AssertExpectations(t)
+		})
+	}
+}
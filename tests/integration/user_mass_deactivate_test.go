@@ -3,12 +3,14 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/entity"
 	"Service-for-assigning-reviewers-for-Pull-Requests/internal/handlers"
@@ -24,7 +26,17 @@ func TestServices_UserMassDeactivateHandler(t *testing.T) {
 				UserID: "u1", Username: "Alice",
 				TeamName: "backend", IsActive: true},
 				{UserID: "u2", Username: "Bob", TeamName: "backend",
-					IsActive: false}}, false).Return(nil)
+					IsActive: false}}, false).Return(&entity.ReassignmentReport{
+			DeactivatedUserIDs: []string{"u1", "u2"},
+			PullRequests: []*entity.PRReassignment{{
+				PullRequestID: "pr-1",
+				Removed:       []string{"u1"},
+				Added:         []string{"u3"},
+				Reviewers:     []string{"u3"},
+			}},
+			Understaffed:     []string{},
+			WithoutReviewers: []string{},
+		}, nil)
 
 		services := &handlers.Services{
 			Log:         newTestLogger(),
@@ -48,10 +60,12 @@ func TestServices_UserMassDeactivateHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var resp handlers.UserMassChangeResponse
+		var resp entity.ReassignmentReport
 		err = json.Unmarshal(w.Body.Bytes(), &resp)
 		assert.NoError(t, err)
-		assert.ElementsMatch(t, []string{"u1", "u2"}, resp.Deactivated)
+		assert.ElementsMatch(t, []string{"u1", "u2"}, resp.DeactivatedUserIDs)
+		assert.Len(t, resp.PullRequests, 1)
+		assert.Equal(t, []string{"u3"}, resp.PullRequests[0].Added)
 
 		userService.AssertExpectations(t)
 	})
@@ -90,7 +104,7 @@ func TestServices_UserMassDeactivateHandler(t *testing.T) {
 
 	t.Run("service returns NOT_FOUND -> 404", func(t *testing.T) {
 		userService := new(MockUserService)
-		userService.On("MassDeactivate", mock.Anything, mock.Anything, false).Return(entity.ErrNotFound)
+		userService.On("MassDeactivate", mock.Anything, mock.Anything, false).Return(nil, entity.ErrNotFound)
 
 		services := &handlers.Services{
 			Log:         newTestLogger(),
@@ -108,4 +122,30 @@ func TestServices_UserMassDeactivateHandler(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 		userService.AssertExpectations(t)
 	})
+
+	t.Run("service returns TEAM_MISMATCH -> 400", func(t *testing.T) {
+		userService := new(MockUserService)
+		userService.On("MassDeactivate", mock.Anything, mock.Anything, false).
+			Return(nil, fmt.Errorf("%w: u1 is a member of team backend, not frontend", entity.ErrTeamMismatch))
+
+		services := &handlers.Services{
+			Log:         newTestLogger(),
+			UserService: userService}
+
+		reqBody := map[string]interface{}{
+			"users": []map[string]interface{}{{
+				"user_id": "u1", "team_name": "frontend"}}, "flag": false}
+		b, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/users/deactivate", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+
+		services.UsersMassDeactivateHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp entity.ErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, entity.CodeTeamMismatch, resp.Error.Code)
+		userService.AssertExpectations(t)
+	})
 }